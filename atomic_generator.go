@@ -0,0 +1,214 @@
+package snowflake
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Lock-free generator of new snowflake IDs. Safe for concurrent use by multiple goroutines.
+//
+// Works like [Generator] and accepts the same [GeneratorConfig], but keeps the timestamp and
+// sequence of the last generated ID in one [atomic.Uint64] and advances it with
+// compare-and-swap instead of locking a mutex. Under heavy contention callers retry instead of
+// queueing; see the benchmarks in the package tests to choose between the two.
+//
+// Create generators with [NewAtomicGenerator]; the zero value is not usable.
+type AtomicGenerator struct {
+	generator
+
+	state atomic.Uint64 // Same as Generator.last.
+	seen  atomic.Uint64 // Same as Generator.seen.
+}
+
+// # Function NewAtomicGenerator(config)
+//
+// Creates a new lock-free snowflake ID generator.
+//
+// # Arguments
+//
+//   - config [GeneratorConfig]: Generator configuration.
+//
+// # Return
+//
+//   - *[AtomicGenerator]: New generator.
+//   - error
+//
+// # Errors
+//
+//   - Same errors as [NewGenerator].
+//
+// # Examples
+//
+//	g, err := snowflake.NewAtomicGenerator(snowflake.GeneratorConfig{WorkerID: 1})
+//	if err != nil {
+//		panic(err)
+//	}
+//	s, _ := g.Generate()
+func NewAtomicGenerator(config GeneratorConfig) (*AtomicGenerator, error) {
+	core, err := newGenerator(config)
+	if err != nil {
+		return nil, err
+	}
+	last, err := core.restore()
+	if err != nil {
+		return nil, err
+	}
+
+	g := &AtomicGenerator{generator: core}
+	g.state.Store(last)
+	g.startCheckpoints(g.state.Load)
+	return g, nil
+}
+
+// # Wrapper for NewAtomicGenerator(config)
+//
+// Wrapper for [NewAtomicGenerator] function. Creates panic if [NewAtomicGenerator] returns an
+// error.
+func MustNewAtomicGenerator(config GeneratorConfig) *AtomicGenerator {
+	g, err := NewAtomicGenerator(config)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// # Method Generate() of AtomicGenerator
+//
+// Generates a new snowflake ID. Same as [AtomicGenerator.NextID] with [context.Background].
+//
+// # Return
+//
+//   - [Snowflake]: New unique snowflake ID.
+//   - error
+//
+// # Errors
+//
+//   - Same errors as [Generator.Generate].
+//
+// (No arguments and examples)
+func (g *AtomicGenerator) Generate() (Snowflake, error) {
+	return g.NextID(context.Background())
+}
+
+// # Method NextID(ctx) of AtomicGenerator
+//
+// Generates a new snowflake ID. Behaves like [Generator.NextID].
+//
+// # Arguments
+//
+//   - ctx [context.Context]: Context that limits how long the call may wait.
+//
+// # Return
+//
+//   - [Snowflake]: New unique snowflake ID.
+//   - error
+//
+// # Errors
+//
+//   - Same errors as [Generator.NextID].
+//
+// (No examples)
+func (g *AtomicGenerator) NextID(ctx context.Context) (Snowflake, error) {
+	state, err := g.run(ctx, 1, 0, g.step)
+	if err != nil {
+		return 0, err
+	}
+	return g.snowflake(state), nil
+}
+
+// # Method Reserve(ctx, n) of AtomicGenerator
+//
+// Atomically reserves a block of n snowflake IDs. Behaves like [Generator.Reserve].
+//
+// # Arguments
+//
+//   - ctx [context.Context]: Context that limits how long the call may wait.
+//   - n int: Number of snowflake IDs to reserve.
+//
+// # Return
+//
+//   - [Range]: Reserved block of snowflake IDs.
+//   - error
+//
+// # Errors
+//
+//   - Same errors as [Generator.Reserve].
+//
+// (No examples)
+func (g *AtomicGenerator) Reserve(ctx context.Context, n int) (Range, error) {
+	return g.reserve(ctx, n, g.step)
+}
+
+// # Method GenerateN(n) of AtomicGenerator
+//
+// Generates n snowflake IDs at once. Same as [AtomicGenerator.Reserve] with
+// [context.Background], converted to a slice.
+//
+// # Arguments
+//
+//   - n int: Number of snowflake IDs to generate.
+//
+// # Return
+//
+//   - []Snowflake: New unique snowflake IDs in increasing order.
+//   - error
+//
+// # Errors
+//
+//   - Same errors as [Generator.Reserve].
+//
+// (No examples)
+func (g *AtomicGenerator) GenerateN(n int) ([]Snowflake, error) {
+	r, err := g.Reserve(context.Background(), n)
+	if err != nil {
+		return nil, err
+	}
+	return r.Slice(), nil
+}
+
+// # Wrapper for Generate()
+//
+// Wrapper for [AtomicGenerator.Generate] method. Creates panic if [AtomicGenerator.Generate]
+// returns an error.
+func (g *AtomicGenerator) MustGenerate() Snowflake {
+	s, err := g.Generate()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Tries to move the generator n states forward. See generator.run and generator.advance.
+func (g *AtomicGenerator) step(n, floor uint64, try bool) (
+	state, until uint64, exhausted bool, err error,
+) {
+	for {
+		// The state must be loaded before reading the clock: otherwise another goroutine could
+		// generate an ID with a later timestamp in between, and it would look like the clock
+		// moved backwards.
+		last := g.state.Load()
+		seen := g.seen.Load()
+
+		wall := g.clock.Now()
+		now, err := g.timestamp(wall)
+		if err != nil {
+			return 0, 0, false, err
+		}
+		if now < seen {
+			// Only one of the goroutines that noticed the regression reports it.
+			if g.seen.CompareAndSwap(seen, now) {
+				g.regressed(seen, now, last, wall)
+			}
+		} else if now > seen {
+			g.seen.CompareAndSwap(seen, now)
+		}
+
+		state, until, exhausted, err = g.advance(last, now, n, floor, try, wall)
+		if err != nil || until != 0 {
+			return state, until, exhausted, err
+		}
+		if g.state.CompareAndSwap(last, state) {
+			return state, 0, false, nil
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package snowflake_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gophercord/snowflake"
+)
+
+func TestAtomicGenerate(t *testing.T) {
+	g := snowflake.MustNewAtomicGenerator(snowflake.GeneratorConfig{WorkerID: 3, ProcessID: 4})
+
+	var prev snowflake.Snowflake
+	for i := 0; i < 10_000; i++ {
+		s := g.MustGenerate()
+
+		if s <= prev {
+			t.Fatalf("FAIL TestAtomicGenerate[%d]: snowflake %d is not greater than previous %d",
+				i, s, prev)
+		}
+		if s.WorkerID() != 3 || s.ProcessID() != 4 {
+			t.Fatalf("FAIL TestAtomicGenerate[%d]: snowflake %d has worker ID %d and process "+
+				"ID %d, wanted 3 and 4",
+				i, s, s.WorkerID(), s.ProcessID())
+		}
+		prev = s
+	}
+}
+
+// Run with -race to check both generators for data races.
+func TestGeneratorsConcurrent(t *testing.T) {
+	const goroutines, perGoroutine = 16, 5_000
+
+	generators := map[string]snowflake.IDGenerator{
+		"mutex":  snowflake.MustNewGenerator(snowflake.GeneratorConfig{}),
+		"atomic": snowflake.MustNewAtomicGenerator(snowflake.GeneratorConfig{}),
+	}
+
+	for name, g := range generators {
+		results := make(chan []snowflake.Snowflake, goroutines)
+
+		var wg sync.WaitGroup
+		for i := 0; i < goroutines; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ids := make([]snowflake.Snowflake, 0, perGoroutine)
+				for j := 0; j < perGoroutine; j++ {
+					s, err := g.Generate()
+					if err != nil {
+						t.Errorf("FAIL TestGeneratorsConcurrent[%s]: Generate returned error %v",
+							name, err)
+						return
+					}
+					ids = append(ids, s)
+				}
+				results <- ids
+			}()
+		}
+		wg.Wait()
+		close(results)
+
+		seen := make(map[snowflake.Snowflake]bool, goroutines*perGoroutine)
+		for ids := range results {
+			for j, s := range ids {
+				if seen[s] {
+					t.Fatalf("FAIL TestGeneratorsConcurrent[%s]: duplicate snowflake %d", name, s)
+				}
+				if j > 0 && s <= ids[j-1] {
+					t.Fatalf("FAIL TestGeneratorsConcurrent[%s]: snowflake %d is not greater "+
+						"than previous %d",
+						name, s, ids[j-1])
+				}
+				seen[s] = true
+			}
+		}
+	}
+}
+
+func BenchmarkGenerate(b *testing.B) {
+	b.Run("mutex", func(b *testing.B) {
+		g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{})
+		for i := 0; i < b.N; i++ {
+			g.MustGenerate()
+		}
+	})
+	b.Run("atomic", func(b *testing.B) {
+		g := snowflake.MustNewAtomicGenerator(snowflake.GeneratorConfig{})
+		for i := 0; i < b.N; i++ {
+			g.MustGenerate()
+		}
+	})
+}
+
+func BenchmarkGenerateParallel(b *testing.B) {
+	b.Run("mutex", func(b *testing.B) {
+		g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{})
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				g.MustGenerate()
+			}
+		})
+	})
+	b.Run("atomic", func(b *testing.B) {
+		g := snowflake.MustNewAtomicGenerator(snowflake.GeneratorConfig{})
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				g.MustGenerate()
+			}
+		})
+	})
+	b.Run("pool", func(b *testing.B) {
+		p := snowflake.MustNewPool(snowflake.PoolConfig{ProcessIDs: []uint8{0, 1, 2, 3}})
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				p.MustGenerate()
+			}
+		})
+	})
+}
@@ -0,0 +1,166 @@
+package snowflake
+
+import "time"
+
+// Bits below the timestamp of a snowflake ID: worker ID, process ID and sequence.
+const lowBits = 1<<22 - 1
+
+// # Function MinForTime(t)
+//
+// Returns the smallest snowflake ID created at or after t. Same as [Decoder.MinForTime] with the
+// package-level [Epoch].
+//
+// # Arguments
+//
+//   - t [time.Time]: Date and time.
+//
+// # Return
+//
+//   - [Snowflake]: Inclusive lower bound.
+//   - bool: False if no snowflake ID is created at or after t.
+//
+// (No errors and examples)
+func MinForTime(t time.Time) (Snowflake, bool) {
+	return Decoder{Epoch: Epoch}.MinForTime(t)
+}
+
+// # Function MaxForTime(t)
+//
+// Returns the largest snowflake ID created at or before t. Same as [Decoder.MaxForTime] with the
+// package-level [Epoch].
+//
+// # Arguments
+//
+//   - t [time.Time]: Date and time.
+//
+// # Return
+//
+//   - [Snowflake]: Inclusive upper bound.
+//   - bool: False if no snowflake ID is created at or before t.
+//
+// (No errors and examples)
+func MaxForTime(t time.Time) (Snowflake, bool) {
+	return Decoder{Epoch: Epoch}.MaxForTime(t)
+}
+
+// # Function BoundsForWindow(from, to)
+//
+// Returns the inclusive range of snowflake IDs created between from and to. Same as
+// [Decoder.BoundsForWindow] with the package-level [Epoch].
+//
+// # Arguments
+//
+//   - from [time.Time]: Start of the window.
+//   - to [time.Time]: End of the window.
+//
+// # Return
+//
+//   - [Snowflake]: Inclusive lower bound.
+//   - [Snowflake]: Inclusive upper bound.
+//
+// # Examples
+//
+//	day := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+//	min, max := snowflake.BoundsForWindow(day, day.AddDate(0, 0, 1).Add(-time.Nanosecond))
+//	// SELECT * FROM messages WHERE id BETWEEN min AND max
+//
+// (No errors)
+func BoundsForWindow(from, to time.Time) (Snowflake, Snowflake) {
+	return Decoder{Epoch: Epoch}.BoundsForWindow(from, to)
+}
+
+// # Method MinForTime(t) of Decoder
+//
+// Returns the smallest snowflake ID created at or after t: its timestamp is t rounded up to whole
+// time units, and all bits below the timestamp are zero. The creation time of an ID is
+// [Decoder.Time], so unlike [Decoder.ParseTime], a t in the middle of a millisecond gives the
+// next millisecond: IDs of the current one were created before t.
+//
+// Times before the epoch give zero. If t is after the creation time of the largest timestamp
+// ([Decoder.MaxTime]), no snowflake ID is created at or after it, and false is returned.
+//
+// # Arguments
+//
+//   - t [time.Time]: Date and time.
+//
+// # Return
+//
+//   - [Snowflake]: Inclusive lower bound.
+//   - bool: False if no snowflake ID is created at or after t.
+//
+// (No errors and examples)
+func (d Decoder) MinForTime(t time.Time) (Snowflake, bool) {
+	b := d.base()
+	ts, err := b.ticks(t, MaxTimestamp)
+	switch err.(type) {
+	case nil:
+		if b.time(ts).Before(t) {
+			if ts == MaxTimestamp {
+				return 0, false
+			}
+			ts++
+		}
+	case *TimeBeforeEpochError:
+		ts = 0
+	case *TimeOverflowError:
+		return 0, false
+	}
+	return Snowflake(ts << 22), true
+}
+
+// # Method MaxForTime(t) of Decoder
+//
+// Returns the largest snowflake ID created at or before t: its timestamp is t rounded down to
+// whole time units, and all 22 bits below the timestamp are set.
+//
+// Times after [Decoder.MaxTime] give the largest snowflake ID. If t is before the epoch, no
+// snowflake ID is created at or before it, and false is returned.
+//
+// # Arguments
+//
+//   - t [time.Time]: Date and time.
+//
+// # Return
+//
+//   - [Snowflake]: Inclusive upper bound.
+//   - bool: False if no snowflake ID is created at or before t.
+//
+// (No errors and examples)
+func (d Decoder) MaxForTime(t time.Time) (Snowflake, bool) {
+	ts, err := d.base().ticks(t, MaxTimestamp)
+	switch err.(type) {
+	case *TimeBeforeEpochError:
+		return 0, false
+	case *TimeOverflowError:
+		ts = MaxTimestamp
+	}
+	return Snowflake(ts<<22 | lowBits), true
+}
+
+// # Method BoundsForWindow(from, to) of Decoder
+//
+// Returns the inclusive range of snowflake IDs created between from and to, both inclusive:
+// [Decoder.MinForTime] of from and [Decoder.MaxForTime] of to. If no creation time fits into the
+// window (from is after to, both are within the same millisecond and neither is at its start,
+// or the window lies entirely before the epoch or after [Decoder.MaxTime]), the lower bound is
+// greater than the upper bound, so a query like "id BETWEEN min AND max" matches nothing.
+//
+// # Arguments
+//
+//   - from [time.Time]: Start of the window.
+//   - to [time.Time]: End of the window.
+//
+// # Return
+//
+//   - [Snowflake]: Inclusive lower bound.
+//   - [Snowflake]: Inclusive upper bound.
+//
+// (No errors and examples)
+func (d Decoder) BoundsForWindow(from, to time.Time) (Snowflake, Snowflake) {
+	min, ok := d.MinForTime(from)
+	max, ok2 := d.MaxForTime(to)
+	if !ok || !ok2 {
+		return 1, 0
+	}
+	return min, max
+}
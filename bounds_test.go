@@ -0,0 +1,100 @@
+package snowflake_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gophercord/snowflake"
+)
+
+func TestBoundsForTime(t *testing.T) {
+	const low = 1<<22 - 1
+	epoch := time.UnixMilli(snowflake.DiscordEpoch)
+	ms := epoch.Add(41944705796 * time.Millisecond)
+
+	tests := []struct {
+		t            time.Time
+		min, max     snowflake.Snowflake
+		minOK, maxOK bool
+	}{
+		{ms, 41944705796 << 22, 41944705796<<22 | low, true, true},
+		// Sub-millisecond times: IDs of this millisecond were created before t.
+		{ms.Add(time.Nanosecond), 41944705797 << 22, 41944705796<<22 | low, true, true},
+		{ms.Add(-time.Nanosecond), 41944705796 << 22, 41944705795<<22 | low, true, true},
+		{epoch, 0, low, true, true},
+		// No IDs are created before the epoch.
+		{epoch.Add(-time.Hour), 0, 0, true, false},
+		{time.Time{}, 0, 0, true, false},
+		// No IDs are created after the largest timestamp.
+		{snowflake.DiscordDecoder.MaxTime(), snowflake.MaxTimestamp << 22, 1<<64 - 1, true, true},
+		{snowflake.DiscordDecoder.MaxTime().Add(time.Nanosecond), 0, 1<<64 - 1, false, true},
+		{time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC), 0, 1<<64 - 1, false, true},
+	}
+
+	for i, test := range tests {
+		if got, ok := snowflake.MinForTime(test.t); got != test.min || ok != test.minOK {
+			t.Errorf("FAIL TestBoundsForTime[%d]: MinForTime %d, %v, want %d, %v",
+				i, got, ok, test.min, test.minOK)
+		}
+		if got, ok := snowflake.MaxForTime(test.t); got != test.max || ok != test.maxOK {
+			t.Errorf("FAIL TestBoundsForTime[%d]: MaxForTime %d, %v, want %d, %v",
+				i, got, ok, test.max, test.maxOK)
+		}
+	}
+}
+
+func TestBoundsForWindow(t *testing.T) {
+	const low = 1<<22 - 1
+	day := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+	min, max := snowflake.BoundsForWindow(day, day.AddDate(0, 0, 1).Add(-time.Nanosecond))
+
+	inside := []time.Time{day, day.Add(12 * time.Hour), day.AddDate(0, 0, 1).Add(-time.Millisecond)}
+	for i, at := range inside {
+		for _, seq := range []uint16{0, snowflake.MaxSequence} {
+			s, _ := snowflake.ParseTime(at).WithSequence(seq)
+			s, _ = s.WithWorkerID(snowflake.MaxWorkerID)
+			if s < min || s > max {
+				t.Errorf("FAIL TestBoundsForWindow[%d]: %d (%s) is outside [%d, %d]",
+					i, s, s.Time(), min, max)
+			}
+		}
+	}
+	outside := []time.Time{day.Add(-time.Millisecond), day.AddDate(0, 0, 1)}
+	for i, at := range outside {
+		if s := snowflake.ParseTime(at); s >= min && s <= max {
+			t.Errorf("FAIL TestBoundsForWindow[%d]: %d (%s) is inside", i, s, s.Time())
+		}
+	}
+
+	// No creation time fits into a window within one millisecond.
+	from := day.Add(100 * time.Microsecond)
+	if min, max := snowflake.BoundsForWindow(from, from.Add(time.Microsecond)); min <= max {
+		t.Errorf("FAIL TestBoundsForWindow: empty window gave [%d, %d]", min, max)
+	}
+
+	// Windows entirely outside of the representable range are empty.
+	epoch := time.UnixMilli(snowflake.DiscordEpoch)
+	maxTime := snowflake.DiscordDecoder.MaxTime()
+	empty := [][2]time.Time{
+		{time.Unix(0, 0), time.Unix(100, 0)},
+		{epoch.Add(-time.Hour), epoch.Add(-time.Millisecond)},
+		{maxTime.Add(time.Nanosecond), maxTime.Add(time.Hour)},
+	}
+	for i, w := range empty {
+		if min, max := snowflake.BoundsForWindow(w[0], w[1]); min <= max {
+			t.Errorf("FAIL TestBoundsForWindow[%d]: window [%s, %s] gave [%d, %d]",
+				i, w[0], w[1], min, max)
+		}
+	}
+	// Windows partly outside of it are clamped.
+	if min, max := snowflake.BoundsForWindow(time.Unix(0, 0), epoch); min != 0 || max != low {
+		t.Errorf("FAIL TestBoundsForWindow: window from 1970 gave [%d, %d]", min, max)
+	}
+
+	// Decoders round to their own time unit.
+	d := snowflake.Decoder{Epoch: snowflake.DiscordEpoch, TimeUnit: time.Second}
+	min, max = d.BoundsForWindow(day.Add(time.Millisecond), day.Add(time.Minute))
+	if !d.Time(min).Equal(day.Add(time.Second)) || !d.Time(max).Equal(day.Add(time.Minute)) {
+		t.Errorf("FAIL TestBoundsForWindow: decoder window [%s, %s]", d.Time(min), d.Time(max))
+	}
+}
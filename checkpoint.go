@@ -0,0 +1,257 @@
+package snowflake
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Default value of [GeneratorConfig.CheckpointInterval].
+const DefaultCheckpointInterval = time.Second
+
+// Writes the high-water timestamp of a generator to a file, so that a restarted generator does
+// not issue snowflake IDs below it.
+//
+// The checkpoint is a reservation: the written timestamp is ahead of the last issued ID by twice
+// the checkpoint interval, and the generator does not return IDs above it until a new checkpoint
+// is written. So after a crash every issued ID is below the checkpoint, even if the clock moved
+// backwards before the restart. Only [Generator.Close] writes the exact timestamp of the last ID.
+type checkpointer struct {
+	path  string
+	base  timeBase
+	now   func() time.Time
+	ahead uint64        // How far the reservation is ahead, in milliseconds.
+	load  func() uint64 // Returns the current generator state.
+
+	// Timestamp of the generator time units up to which IDs may be returned.
+	limit atomic.Uint64
+
+	mu      sync.Mutex // Serializes writes.
+	written uint64     // Last written Unix timestamp in milliseconds.
+	closed  bool       // Whether the final checkpoint is written.
+	err     error      // First error of a periodic write.
+
+	once sync.Once
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Reads the checkpoint file and returns the generator state that follows it: every snowflake ID
+// of that state or later is above the checkpoint. Returns zero if there is no checkpoint yet.
+func (g *generator) restore() (uint64, error) {
+	if g.checkpointPath == "" {
+		return 0, nil
+	}
+
+	data, err := os.ReadFile(g.checkpointPath)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, newCheckpointError("unable to read checkpoint "+g.checkpointPath, err)
+	}
+
+	ms, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, newCheckpointError("malformed checkpoint "+g.checkpointPath, err)
+	}
+	t := time.UnixMilli(int64(ms))
+	ts, err := g.base.ticks(t, MaxTimestamp)
+	switch err.(type) {
+	case nil:
+	case *TimeBeforeEpochError:
+		return 0, nil
+	default:
+		return 0, newCheckpointError("checkpoint "+g.checkpointPath+" is out of range", err)
+	}
+	return ts<<12 | MaxSequence, nil
+}
+
+// Starts writing checkpoints of the state returned by load, if the generator has a checkpoint
+// path.
+func (g *generator) startCheckpoints(load func() uint64) {
+	if g.checkpointPath == "" {
+		return
+	}
+
+	interval := g.checkpointInterval
+	if interval < 0 {
+		interval = DefaultCheckpointInterval
+	}
+	ahead := uint64((2*interval + time.Millisecond - 1) / time.Millisecond)
+
+	c := &checkpointer{
+		path:  g.checkpointPath,
+		base:  g.base,
+		now:   g.clock.Now,
+		ahead: ahead,
+		load:  load,
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	g.checkpoint = c
+
+	if g.checkpointInterval < 0 {
+		close(c.done)
+		return
+	}
+	go c.run(g.checkpointInterval)
+}
+
+// # Method Close() of Generator
+//
+// Stops periodic checkpoints and writes the final checkpoint: the exact timestamp of the last
+// generated snowflake ID, so that a restarted generator does not have to wait for the
+// reservation of the previous checkpoints. Does nothing if the generator has no checkpoint path.
+// Snowflake IDs generated after Close are not checkpointed.
+//
+// # Return
+//
+//   - error
+//
+// # Errors
+//
+//   - [CheckpointError]: If the final or a previous periodic checkpoint could not be written.
+//
+// (No arguments and examples)
+func (g *generator) Close() error {
+	c := g.checkpoint
+	if c == nil {
+		return nil
+	}
+
+	var err error
+	c.once.Do(func() {
+		close(c.stop)
+		<-c.done
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		err = c.write(true)
+		c.closed = true
+		if c.err != nil {
+			err = c.err
+		}
+	})
+	return err
+}
+
+// Makes sure that the checkpoint is above state before its snowflake ID is returned, writing a
+// new checkpoint if the state is past the reservation of the last one.
+func (g *generator) covered(state uint64) error {
+	c := g.checkpoint
+	if c == nil || state>>12 <= c.limit.Load() {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed || state>>12 <= c.limit.Load() {
+		return nil
+	}
+	return c.write(false)
+}
+
+func (c *checkpointer) run(interval time.Duration) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.mu.Lock()
+			if err := c.write(false); err != nil && c.err == nil {
+				c.err = err
+			}
+			c.mu.Unlock()
+		}
+	}
+}
+
+// Writes a new checkpoint, if it changed. If final is false, the checkpoint reserves c.ahead
+// milliseconds after the current time or the last ID, whichever is later; it is extended only
+// when that time gets within half of c.ahead of the reservation. So the periodic writes keep the
+// reservation ahead of the clock even while no IDs are generated, and the next ID does not have
+// to wait for a write. If final is true, the checkpoint is the timestamp of the last ID. c.mu
+// must be held.
+//
+// The file is replaced atomically: the timestamp is written and synced to a temporary file that
+// is then renamed over the checkpoint.
+func (c *checkpointer) write(final bool) error {
+	state := c.load()
+	if state == 0 {
+		return nil
+	}
+	// Round up to whole milliseconds, so that the checkpoint is never below the last ID.
+	t := c.base.time(state >> 12)
+	ms := uint64(t.UnixMilli())
+	if t.After(time.UnixMilli(int64(ms))) {
+		ms++
+	}
+	if !final {
+		if now := c.now().UnixMilli(); now > int64(ms) {
+			ms = uint64(now)
+		}
+		if c.written != 0 && ms+c.ahead/2 <= c.written && state>>12 <= c.limit.Load() {
+			return nil
+		}
+		ms += c.ahead
+	}
+	if ms == c.written {
+		return nil
+	}
+
+	dir, name := filepath.Split(c.path)
+	if dir == "" {
+		dir = "."
+	}
+	tmp, err := os.CreateTemp(dir, name+".tmp*")
+	if err != nil {
+		return newCheckpointError("unable to create checkpoint "+c.path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.WriteString(strconv.FormatUint(ms, 10) + "\n")
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path)
+	}
+	if err != nil {
+		return newCheckpointError("unable to write checkpoint "+c.path, err)
+	}
+
+	// Sync the directory, so that the rename survives a crash. Not supported everywhere (for
+	// example on Windows), so errors are ignored.
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+
+	c.written = ms
+	if final {
+		return nil
+	}
+	limit, err := c.base.ticks(time.UnixMilli(int64(ms)), MaxTimestamp)
+	if _, ok := err.(*TimeOverflowError); ok {
+		limit = MaxTimestamp
+	}
+	c.limit.Store(limit)
+	return nil
+}
+
+func newCheckpointError(message string, err error) *CheckpointError {
+	return &CheckpointError{SnowflakeError: SnowflakeError{message: message, err: err}}
+}
@@ -0,0 +1,177 @@
+package snowflake_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gophercord/snowflake"
+)
+
+func TestCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "generator.checkpoint")
+	clock := snowflake.NewManualClock(start)
+
+	g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{
+		Clock:              clock,
+		CheckpointPath:     path,
+		CheckpointInterval: -1,
+	})
+	first := g.MustGenerate()
+	if err := g.Close(); err != nil {
+		t.Fatalf("FAIL TestCheckpoint: Close returned error %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || strings.TrimSpace(string(data)) != strconv.FormatInt(start.UnixMilli(), 10) {
+		t.Fatalf("FAIL TestCheckpoint: checkpoint is %q (%v), wanted %d",
+			data, err, start.UnixMilli())
+	}
+
+	// Restart with the clock one second behind the checkpoint.
+	clock.Advance(-time.Second)
+
+	g = snowflake.MustNewGenerator(snowflake.GeneratorConfig{
+		Clock:          clock,
+		ClockPolicy:    snowflake.ClockPolicyError,
+		CheckpointPath: path,
+	})
+	defer g.Close()
+
+	var backwards *snowflake.ClockMovedBackwardsError
+	if _, err := g.Generate(); !errors.As(err, &backwards) {
+		t.Errorf("FAIL TestCheckpoint: wanted ClockMovedBackwardsError, got %v", err)
+	}
+
+	clock.Advance(time.Second + time.Millisecond)
+	s, err := g.Generate()
+	if err != nil || s <= first {
+		t.Errorf("FAIL TestCheckpoint: wanted snowflake above %d, got %d, %v", first, s, err)
+	}
+}
+
+func TestCheckpointCrash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "generator.checkpoint")
+	clock := snowflake.NewManualClock(start)
+
+	// The generator is never closed, as if the process crashed.
+	g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{
+		Clock:              clock,
+		CheckpointPath:     path,
+		CheckpointInterval: -1,
+	})
+	first := g.MustGenerate()
+
+	data, err := os.ReadFile(path)
+	ms, _ := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil || ms != start.Add(2*snowflake.DefaultCheckpointInterval).UnixMilli() {
+		t.Fatalf("FAIL TestCheckpointCrash: checkpoint is %q (%v), wanted reservation after %d",
+			data, err, start.UnixMilli())
+	}
+
+	// Restart with the clock slightly behind the last ID.
+	clock.Advance(-500 * time.Millisecond)
+	g = snowflake.MustNewGenerator(snowflake.GeneratorConfig{
+		Clock:          clock,
+		ClockPolicy:    snowflake.ClockPolicyError,
+		CheckpointPath: path,
+	})
+	defer g.Close()
+
+	var backwards *snowflake.ClockMovedBackwardsError
+	for _, d := range []time.Duration{0, 501 * time.Millisecond} {
+		clock.Advance(d)
+		if _, err := g.Generate(); !errors.As(err, &backwards) {
+			t.Errorf("FAIL TestCheckpointCrash: wanted ClockMovedBackwardsError at %s, got %v",
+				clock.Now(), err)
+		}
+	}
+
+	clock.Set(time.UnixMilli(ms + 1))
+	s, err := g.Generate()
+	if err != nil || s <= first {
+		t.Errorf("FAIL TestCheckpointCrash: wanted snowflake above %d, got %d, %v", first, s, err)
+	}
+}
+
+func TestCheckpointWriteError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "generator.checkpoint")
+	g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{
+		CheckpointPath:     path,
+		CheckpointInterval: -1,
+	})
+
+	var checkpointErr *snowflake.CheckpointError
+	if _, err := g.Generate(); !errors.As(err, &checkpointErr) {
+		t.Errorf("FAIL TestCheckpointWriteError: wanted CheckpointError, got %v", err)
+	}
+}
+
+func TestCheckpointLogical(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "generator.checkpoint")
+	os.WriteFile(path, []byte(strconv.FormatInt(start.UnixMilli(), 10)), 0o644)
+
+	clock := snowflake.NewManualClock(start.Add(-time.Second))
+	g := snowflake.MustNewAtomicGenerator(snowflake.GeneratorConfig{
+		Clock:          clock,
+		ClockPolicy:    snowflake.ClockPolicyLogical,
+		CheckpointPath: path,
+	})
+	defer g.Close()
+
+	s, err := g.Generate()
+	if err != nil || !s.Time().After(start) {
+		t.Errorf("FAIL TestCheckpointLogical: wanted snowflake after %s, got %s (%v)",
+			start, s.Time(), err)
+	}
+}
+
+func TestCheckpointPeriodic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "generator.checkpoint")
+	clock := snowflake.NewManualClock(start)
+	g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{
+		Clock:              clock,
+		CheckpointPath:     path,
+		CheckpointInterval: 5 * time.Millisecond,
+	})
+	defer g.Close()
+
+	// The first ID writes the checkpoint 10 ms ahead.
+	g.MustGenerate()
+	read := func() int64 {
+		data, _ := os.ReadFile(path)
+		ms, _ := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+		return ms
+	}
+	if ms := read(); ms != start.UnixMilli()+10 {
+		t.Fatalf("FAIL TestCheckpointPeriodic: checkpoint is %d, wanted %d",
+			ms, start.UnixMilli()+10)
+	}
+
+	// Only the periodic writes can move the checkpoint past the clock: no IDs are generated.
+	clock.Advance(time.Hour)
+	want := start.Add(time.Hour).UnixMilli() + 10
+	deadline := time.Now().Add(time.Second)
+	for read() != want && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if ms := read(); ms != want {
+		t.Errorf("FAIL TestCheckpointPeriodic: checkpoint is %d, wanted %d", ms, want)
+	}
+}
+
+func TestCheckpointMalformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "generator.checkpoint")
+	os.WriteFile(path, []byte("not a timestamp"), 0o644)
+
+	_, err := snowflake.NewGenerator(snowflake.GeneratorConfig{CheckpointPath: path})
+
+	var checkpointErr *snowflake.CheckpointError
+	if !errors.As(err, &checkpointErr) {
+		t.Errorf("FAIL TestCheckpointMalformed: wanted CheckpointError, got %v", err)
+	}
+}
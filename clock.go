@@ -0,0 +1,250 @@
+package snowflake
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Source of the current time for [Generator] and [AtomicGenerator].
+//
+// Use [SystemClock] in production and [ManualClock] in tests.
+type Clock interface {
+	// Returns the current time.
+	Now() time.Time
+
+	// Waits until d passes on this clock. Returns error of ctx ([context.Canceled] or
+	// [context.DeadlineExceeded]) if ctx is done before that.
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// Clock that reads the system wall clock. Default clock of generators.
+type SystemClock struct{}
+
+// # Method Now() of SystemClock
+//
+// Returns [time.Now].
+//
+// # Return
+//
+//   - [time.Time]: Current system time.
+//
+// (No arguments, errors, and examples)
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// # Method Sleep(ctx, d) of SystemClock
+//
+// Waits until d passes or ctx is done.
+//
+// # Arguments
+//
+//   - ctx [context.Context]: Context that limits how long the call may wait.
+//   - d [time.Duration]: Duration to wait.
+//
+// # Return
+//
+//   - error
+//
+// # Errors
+//
+//   - Error of ctx: If ctx is done before d passes.
+//
+// (No examples)
+func (SystemClock) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Clock that is controlled manually, for deterministic tests. Safe for concurrent use by
+// multiple goroutines.
+//
+// A manual clock is frozen when created: its time changes only with [ManualClock.Set] and
+// [ManualClock.Advance], which can also move it backwards to simulate clock skew. After
+// [ManualClock.Unfreeze] its time also flows together with the system clock, until
+// [ManualClock.Freeze] is called.
+//
+// Create manual clocks with [NewManualClock]; the zero value is not usable.
+type ManualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	since  time.Time // System time when now was set, if the clock is not frozen.
+	frozen bool
+	change chan struct{} // Closed and replaced every time the clock is changed.
+}
+
+// # Function NewManualClock(t)
+//
+// Creates a new frozen manual clock that shows time t.
+//
+// # Arguments
+//
+//   - t [time.Time]: Initial time of the clock.
+//
+// # Return
+//
+//   - *[ManualClock]: New clock.
+//
+// # Examples
+//
+//	clock := snowflake.NewManualClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+//	g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{Clock: clock})
+//	a := g.MustGenerate()
+//	clock.Advance(time.Second)
+//	b := g.MustGenerate()
+//	fmt.Println(b.Time().Sub(a.Time())) // 1s
+//
+// (No errors)
+func NewManualClock(t time.Time) *ManualClock {
+	return &ManualClock{now: t, frozen: true, change: make(chan struct{})}
+}
+
+// # Method Now() of ManualClock
+//
+// Returns current time of the clock.
+//
+// # Return
+//
+//   - [time.Time]: Current time.
+//
+// (No arguments, errors, and examples)
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.current()
+}
+
+// # Method Sleep(ctx, d) of ManualClock
+//
+// Waits until the clock shows time at least d later than now, or ctx is done. While the clock
+// is frozen, only [ManualClock.Set] and [ManualClock.Advance] can end the wait.
+//
+// # Arguments
+//
+//   - ctx [context.Context]: Context that limits how long the call may wait.
+//   - d [time.Duration]: Duration to wait.
+//
+// # Return
+//
+//   - error
+//
+// # Errors
+//
+//   - Error of ctx: If ctx is done before d passes.
+//
+// (No examples)
+func (c *ManualClock) Sleep(ctx context.Context, d time.Duration) error {
+	deadline := c.Now().Add(d)
+
+	for {
+		c.mu.Lock()
+		left := deadline.Sub(c.current())
+		frozen, change := c.frozen, c.change
+		c.mu.Unlock()
+
+		if left <= 0 {
+			return nil
+		}
+
+		var timer *time.Timer
+		var timeout <-chan time.Time
+		if !frozen {
+			timer = time.NewTimer(left)
+			timeout = timer.C
+		}
+
+		var err error
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+		case <-change:
+		case <-timeout:
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// # Method Set(t) of ManualClock
+//
+// Sets the clock to time t. Time t may be before the current time of the clock.
+//
+// # Arguments
+//
+//   - t [time.Time]: New time of the clock.
+//
+// (No return, errors, and examples)
+func (c *ManualClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now, c.since = t, time.Now()
+	c.changed()
+}
+
+// # Method Advance(d) of ManualClock
+//
+// Moves the clock forward by d. Negative d moves the clock backwards.
+//
+// # Arguments
+//
+//   - d [time.Duration]: Duration to move the clock by.
+//
+// (No return, errors, and examples)
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now, c.since = c.current().Add(d), time.Now()
+	c.changed()
+}
+
+// # Method Freeze() of ManualClock
+//
+// Stops the clock: its time changes only with [ManualClock.Set] and [ManualClock.Advance].
+//
+// (No arguments, return, errors, and examples)
+func (c *ManualClock) Freeze() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now, c.frozen = c.current(), true
+	c.changed()
+}
+
+// # Method Unfreeze() of ManualClock
+//
+// Starts the clock: its time flows together with the system clock, starting from the current
+// time of the clock.
+//
+// (No arguments, return, errors, and examples)
+func (c *ManualClock) Unfreeze() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now, c.since, c.frozen = c.current(), time.Now(), false
+	c.changed()
+}
+
+// Must be called with the clock locked.
+func (c *ManualClock) current() time.Time {
+	if c.frozen {
+		return c.now
+	}
+	return c.now.Add(time.Since(c.since))
+}
+
+// Wakes up sleeping goroutines. Must be called with the clock locked.
+func (c *ManualClock) changed() {
+	close(c.change)
+	c.change = make(chan struct{})
+}
@@ -0,0 +1,80 @@
+package snowflake_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gophercord/snowflake"
+)
+
+var start = time.Date(2025, time.April, 10, 12, 0, 0, 0, time.UTC)
+
+func TestManualClock(t *testing.T) {
+	clock := snowflake.NewManualClock(start)
+
+	if now := clock.Now(); !now.Equal(start) {
+		t.Errorf("FAIL TestManualClock: new clock shows %s, wanted %s", now, start)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	if now := clock.Now(); !now.Equal(start) {
+		t.Errorf("FAIL TestManualClock: frozen clock moved to %s", now)
+	}
+
+	clock.Advance(time.Minute)
+	if now := clock.Now(); !now.Equal(start.Add(time.Minute)) {
+		t.Errorf("FAIL TestManualClock: Advance(1m) moved clock to %s", now)
+	}
+
+	clock.Advance(-2 * time.Minute)
+	if now := clock.Now(); !now.Equal(start.Add(-time.Minute)) {
+		t.Errorf("FAIL TestManualClock: Advance(-2m) moved clock to %s", now)
+	}
+
+	clock.Set(start)
+	clock.Unfreeze()
+	time.Sleep(5 * time.Millisecond)
+	if now := clock.Now(); !now.After(start) {
+		t.Errorf("FAIL TestManualClock: unfrozen clock did not move (%s)", now)
+	}
+
+	clock.Freeze()
+	frozen := clock.Now()
+	time.Sleep(5 * time.Millisecond)
+	if now := clock.Now(); !now.Equal(frozen) {
+		t.Errorf("FAIL TestManualClock: frozen clock moved from %s to %s", frozen, now)
+	}
+}
+
+func TestManualClockSleep(t *testing.T) {
+	clock := snowflake.NewManualClock(start)
+
+	done := make(chan error)
+	go func() { done <- clock.Sleep(context.Background(), time.Second) }()
+
+	clock.Advance(500 * time.Millisecond)
+	select {
+	case <-done:
+		t.Fatalf("FAIL TestManualClockSleep: Sleep(1s) returned after 500ms")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// Sleep could start after the first Advance, so move the clock far enough for both cases.
+	clock.Advance(time.Second)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("FAIL TestManualClockSleep: Sleep returned error %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("FAIL TestManualClockSleep: Sleep(1s) did not return after 1.5s")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := clock.Sleep(ctx, time.Second); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("FAIL TestManualClockSleep: wanted context.DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,177 @@
+package snowflake
+
+import (
+	"fmt"
+	"time"
+)
+
+// # Method Age(now) of Snowflake
+//
+// Returns how much time has passed between snowflake creation and now. Negative if now is
+// before the creation time. Reads the package-level [Epoch].
+//
+// # Arguments
+//
+//   - now [time.Time]: Current date and time, usually time.Now().
+//
+// # Return
+//
+//   - [time.Duration]: Age of the snowflake ID.
+//
+// # Examples
+//
+//	if s.Age(time.Now()) < 7*24*time.Hour {
+//		fmt.Println("account is less than a week old")
+//	}
+//
+// (No errors)
+func (s Snowflake) Age(now time.Time) time.Duration {
+	return now.Sub(s.Time())
+}
+
+// # Method Sub(other) of Snowflake
+//
+// Returns the time between creation of other and creation of snowflake ID. Negative if other
+// was created later. Does not depend on [Epoch].
+//
+// # Arguments
+//
+//   - other [Snowflake]: Snowflake ID to compare with.
+//
+// # Return
+//
+//   - [time.Duration]: Difference of the creation times.
+//
+// (No errors and examples)
+func (s Snowflake) Sub(other Snowflake) time.Duration {
+	return time.Duration(int64(discord.Timestamp(s))-int64(discord.Timestamp(other))) *
+		time.Millisecond
+}
+
+// # Method AddTime(d) of Snowflake
+//
+// Returns a copy of snowflake ID with the creation time moved by d, rounded toward zero to whole
+// milliseconds. Only the timestamp changes; worker ID, process ID and sequence are left as they
+// are.
+//
+// # Arguments
+//
+//   - d [time.Duration]: How far to move the creation time. May be negative.
+//
+// # Return
+//
+//   - [Snowflake]: New snowflake ID.
+//   - error
+//
+// # Errors
+//
+//   - [TimeBeforeEpochError]: If the new creation time would be before the epoch.
+//   - [TimeOverflowError]: If the new timestamp does not fit into 42 bits.
+//
+// # Examples
+//
+//	s := snowflake.Snowflake(175928847299117063)
+//	dayLater, _ := s.AddTime(24 * time.Hour)
+//	fmt.Println(dayLater.Sub(s)) // 24h0m0s
+func (s Snowflake) AddTime(d time.Duration) (Snowflake, error) {
+	p := s.Deconstruct()
+	ms := d / time.Millisecond
+
+	switch {
+	case ms < 0 && uint64(-ms) > p.Timestamp:
+		return 0, &TimeBeforeEpochError{SnowflakeError: SnowflakeError{
+			message: fmt.Sprintf("creation time of %d moved by %s is before epoch", s, d),
+		}}
+	case ms > 0 && uint64(ms) > MaxTimestamp-p.Timestamp:
+		return 0, &TimeOverflowError{SnowflakeError: SnowflakeError{
+			message: fmt.Sprintf("creation time of %d moved by %s does not fit into 42 bits",
+				s, d),
+		}}
+	}
+
+	p.Timestamp = uint64(int64(p.Timestamp) + int64(ms))
+	return Compose(p)
+}
+
+// # Method Compare(other) of Snowflake
+//
+// Compares creation times of snowflake ID and other. Snowflake IDs created in the same
+// millisecond compare as equal, even if their worker IDs, process IDs or sequences differ; compare
+// the values (s < other) for a total order that also respects the sequence.
+//
+// # Arguments
+//
+//   - other [Snowflake]: Snowflake ID to compare with.
+//
+// # Return
+//
+//   - int: -1 if snowflake ID was created before other, +1 if after, and 0 in the same
+//     millisecond.
+//
+// (No errors and examples)
+func (s Snowflake) Compare(other Snowflake) int {
+	a, b := discord.Timestamp(s), discord.Timestamp(other)
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// # Method Before(other) of Snowflake
+//
+// Reports whether snowflake ID was created before other. False for snowflake IDs created in the
+// same millisecond, see [Snowflake.Compare].
+//
+// # Arguments
+//
+//   - other [Snowflake]: Snowflake ID to compare with.
+//
+// # Return
+//
+//   - bool: True if the creation time is earlier.
+//
+// (No errors and examples)
+func (s Snowflake) Before(other Snowflake) bool {
+	return s.Compare(other) < 0
+}
+
+// # Method After(other) of Snowflake
+//
+// Reports whether snowflake ID was created after other. False for snowflake IDs created in the
+// same millisecond, see [Snowflake.Compare].
+//
+// # Arguments
+//
+//   - other [Snowflake]: Snowflake ID to compare with.
+//
+// # Return
+//
+//   - bool: True if the creation time is later.
+//
+// (No errors and examples)
+func (s Snowflake) After(other Snowflake) bool {
+	return s.Compare(other) > 0
+}
+
+// # Method Between(start, end) of Snowflake
+//
+// Reports whether snowflake ID was created between start and end, inclusive. Snowflake IDs
+// created in the same millisecond as start or end are included, whatever their sequence, see
+// [Snowflake.Compare].
+//
+// # Arguments
+//
+//   - start [Snowflake]: Lower bound.
+//   - end [Snowflake]: Upper bound.
+//
+// # Return
+//
+//   - bool: True if the creation time is within the bounds.
+//
+// (No errors and examples)
+func (s Snowflake) Between(start, end Snowflake) bool {
+	return s.Compare(start) >= 0 && s.Compare(end) <= 0
+}
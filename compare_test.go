@@ -0,0 +1,91 @@
+package snowflake_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gophercord/snowflake"
+)
+
+func TestCompare(t *testing.T) {
+	s := snowflake.Snowflake(175928847299117063)
+	sameMs, _ := s.WithSequence(4095)
+	sameMsOther, _ := s.WithWorkerID(0)
+	later, _ := s.AddTime(time.Millisecond)
+
+	tests := []struct {
+		a, b    snowflake.Snowflake
+		compare int
+	}{
+		{s, s, 0},
+		{s, sameMs, 0},
+		{sameMsOther, s, 0},
+		{s, later, -1},
+		{later, sameMs, 1},
+	}
+
+	for i, test := range tests {
+		if got := test.a.Compare(test.b); got != test.compare {
+			t.Errorf("FAIL TestCompare[%d]: Compare %d, want %d", i, got, test.compare)
+		}
+		if got := test.a.Before(test.b); got != (test.compare < 0) {
+			t.Errorf("FAIL TestCompare[%d]: Before %v", i, got)
+		}
+		if got := test.a.After(test.b); got != (test.compare > 0) {
+			t.Errorf("FAIL TestCompare[%d]: After %v", i, got)
+		}
+		if got := test.a.Sub(test.b); got != time.Duration(test.compare)*time.Millisecond {
+			t.Errorf("FAIL TestCompare[%d]: Sub %s", i, got)
+		}
+	}
+
+	if !sameMs.Between(s, s) || !s.Between(sameMsOther, later) || later.Between(s, sameMs) {
+		t.Errorf("FAIL TestCompare: Between does not compare creation times")
+	}
+}
+
+func TestAddTime(t *testing.T) {
+	s := snowflake.Snowflake(175928847299117063)
+
+	tests := []struct {
+		d    time.Duration
+		want time.Duration // Expected s.Sub of the result.
+		err  error
+	}{
+		{24 * time.Hour, 24 * time.Hour, nil},
+		{-time.Hour, -time.Hour, nil},
+		{1999 * time.Microsecond, time.Millisecond, nil},
+		{-1999 * time.Microsecond, -time.Millisecond, nil},
+		{-41944705796 * time.Millisecond, -41944705796 * time.Millisecond, nil},
+		{-41944705797 * time.Millisecond, 0, &snowflake.TimeBeforeEpochError{}},
+		{200 * 365 * 24 * time.Hour, 0, &snowflake.TimeOverflowError{}},
+	}
+
+	for i, test := range tests {
+		got, err := s.AddTime(test.d)
+		if !sameErrorType(err, test.err) {
+			t.Errorf("FAIL TestAddTime[%d]: got error %v, want %T", i, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if d := got.Sub(s); d != test.want {
+			t.Errorf("FAIL TestAddTime[%d]: moved by %s, want %s", i, d, test.want)
+		}
+		if got.WorkerID() != 1 || got.ProcessID() != 0 || got.Sequence() != 7 {
+			t.Errorf("FAIL TestAddTime[%d]: fields changed: %+v", i, got.Deconstruct())
+		}
+	}
+}
+
+func TestAge(t *testing.T) {
+	s := snowflake.Snowflake(175928847299117063)
+	now := s.Time().Add(90 * time.Minute)
+	if got := s.Age(now); got != 90*time.Minute {
+		t.Errorf("FAIL TestAge: got %s, want %s", got, 90*time.Minute)
+	}
+	if got := s.Age(s.Time().Add(-time.Second)); got != -time.Second {
+		t.Errorf("FAIL TestAge: got %s, want %s", got, -time.Second)
+	}
+}
@@ -0,0 +1,69 @@
+package snowflake
+
+// Mapping of fields for [Convert]: names of target layout fields to names of source layout
+// fields. Target fields that are not in the map are set to zero; source fields that are not in
+// the map are dropped.
+type FieldMap map[string]string
+
+// # Function Convert(id, from, to, fields)
+//
+// Re-encodes a snowflake ID from one layout to another. The creation time is preserved (rounded
+// down to whole time units of the target layout, if they are longer), and field values are copied
+// as described by fields. Values are never truncated: if a value does not fit into its target
+// field, an error is returned.
+//
+// # Arguments
+//
+//   - id [Snowflake]: Snowflake ID in the source layout.
+//   - from *[Layout]: Source layout.
+//   - to *[Layout]: Target layout.
+//   - fields [FieldMap]: Target field names mapped to source field names.
+//
+// # Return
+//
+//   - [Snowflake]: Snowflake ID in the target layout.
+//   - error
+//
+// # Errors
+//
+//   - [FieldOverflowError]: If id has bits set above the width of the source layout.
+//   - [LayoutError]: If fields names a field that does not exist in its layout.
+//   - [TimeBeforeEpochError]: If the creation time is before the target layout epoch.
+//   - [TimeOverflowError]: If the creation time does not fit into the target layout.
+//   - [FieldOverflowError]: If a field value does not fit into its target field.
+//
+// # Examples
+//
+//	// Twitter datacenter ID and worker ID become Discord worker ID and process ID.
+//	s, _ := snowflake.Convert(1050118621198921728, snowflake.LayoutTwitterSplit,
+//		snowflake.LayoutDiscord, snowflake.FieldMap{
+//			snowflake.FieldWorkerID:  snowflake.FieldDatacenterID,
+//			snowflake.FieldProcessID: snowflake.FieldWorkerID,
+//			snowflake.FieldSequence:  snowflake.FieldSequence,
+//		})
+//	fmt.Println(s, s.WorkerID(), s.ProcessID()) // 499677351741075456 10 27
+func Convert(id Snowflake, from, to *Layout, fields FieldMap) (Snowflake, error) {
+	if !from.fits(id) {
+		return 0, newFieldOverflowError("snowflake ID", uint64(id), mask(from.bits))
+	}
+
+	d := Decoded{Fields: make([]uint64, len(to.fields))}
+	for target, source := range fields {
+		i := to.index(target)
+		if i < 0 {
+			return 0, newLayoutError("layout %q has no field %q", to.name, target)
+		}
+		j := from.index(source)
+		if j < 0 {
+			return 0, newLayoutError("layout %q has no field %q", from.name, source)
+		}
+		d.Fields[i] = from.field(id, j)
+	}
+
+	ts, err := to.TimestampOf(from.Time(id))
+	if err != nil {
+		return 0, err
+	}
+	d.Timestamp = ts
+	return to.Encode(d)
+}
@@ -0,0 +1,100 @@
+package snowflake_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gophercord/snowflake"
+)
+
+func TestConvert(t *testing.T) {
+	// Example Tweet from the Twitter API v2 documentation, and the same Tweet with a sequence.
+	tweet := snowflake.Snowflake(1050118621198921728)
+	twitter := tweet | 42
+	discordFields := snowflake.FieldMap{
+		snowflake.FieldWorkerID:  snowflake.FieldDatacenterID,
+		snowflake.FieldProcessID: snowflake.FieldWorkerID,
+		snowflake.FieldSequence:  snowflake.FieldSequence,
+	}
+
+	tests := []struct {
+		id     snowflake.Snowflake
+		from   *snowflake.Layout
+		to     *snowflake.Layout
+		fields snowflake.FieldMap
+		err    error
+	}{
+		{tweet, snowflake.LayoutTwitterSplit, snowflake.LayoutDiscord, discordFields, nil},
+		{twitter, snowflake.LayoutTwitterSplit, snowflake.LayoutDiscord, discordFields, nil},
+		// The highest bit is not part of the Twitter layout.
+		{1<<63 | tweet, snowflake.LayoutTwitterSplit, snowflake.LayoutDiscord, discordFields,
+			&snowflake.FieldOverflowError{}},
+		{175928847299117063, snowflake.LayoutDiscord, snowflake.LayoutTwitterSplit,
+			snowflake.FieldMap{
+				snowflake.FieldDatacenterID: snowflake.FieldWorkerID,
+				snowflake.FieldWorkerID:     snowflake.FieldProcessID,
+			}, nil},
+		{175928847299117063 | 0xFFF, snowflake.LayoutDiscord, snowflake.LayoutSonyflake,
+			snowflake.FieldMap{snowflake.FieldSequence: snowflake.FieldSequence},
+			&snowflake.FieldOverflowError{}},
+		// Twitter machine IDs do not fit into Discord worker IDs.
+		{tweet, snowflake.LayoutTwitter, snowflake.LayoutDiscord,
+			snowflake.FieldMap{snowflake.FieldWorkerID: snowflake.FieldMachineID},
+			&snowflake.FieldOverflowError{}},
+		// Twitter IDs from 2010 are before the Discord epoch.
+		{1 << 22, snowflake.LayoutTwitter, snowflake.LayoutDiscord, nil,
+			&snowflake.TimeBeforeEpochError{}},
+		// Mastodon timestamps run until the year 10889.
+		{1 << 63, snowflake.LayoutMastodon, snowflake.LayoutDiscord, nil,
+			&snowflake.TimeOverflowError{}},
+		{twitter, snowflake.LayoutTwitterSplit, snowflake.LayoutDiscord,
+			snowflake.FieldMap{"shard ID": snowflake.FieldSequence}, &snowflake.LayoutError{}},
+		{twitter, snowflake.LayoutTwitterSplit, snowflake.LayoutDiscord,
+			snowflake.FieldMap{snowflake.FieldSequence: "shard ID"}, &snowflake.LayoutError{}},
+	}
+
+	for i, test := range tests {
+		s, err := snowflake.Convert(test.id, test.from, test.to, test.fields)
+		if !sameErrorType(err, test.err) {
+			t.Errorf("FAIL TestConvert[%d]: got error %v, want %T", i, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		if from, to := test.from.Time(test.id), test.to.Time(s); !from.Equal(to) {
+			t.Errorf("FAIL TestConvert[%d]: time %s, want %s", i, to, from)
+		}
+		for target, source := range test.fields {
+			want, _ := test.from.Field(test.id, source)
+			if got, _ := test.to.Field(s, target); got != want {
+				t.Errorf("FAIL TestConvert[%d]: %s is %d, want %d", i, target, got, want)
+			}
+		}
+	}
+}
+
+func TestConvertTimeUnit(t *testing.T) {
+	// Sonyflake counts time in 10 ms ticks, so the time is rounded down.
+	s, err := snowflake.Convert(175928847299117063, snowflake.LayoutDiscord,
+		snowflake.LayoutSonyflake, nil)
+	want := time.Date(2016, 4, 30, 11, 18, 25, 790e6, time.UTC)
+	if err != nil || !snowflake.LayoutSonyflake.Time(s).Equal(want) {
+		t.Errorf("FAIL TestConvertTimeUnit: got %s, %v, want %s",
+			snowflake.LayoutSonyflake.Time(s), err, want)
+	}
+}
+
+func TestConvertExample(t *testing.T) {
+	s, err := snowflake.Convert(1050118621198921728, snowflake.LayoutTwitterSplit,
+		snowflake.LayoutDiscord, snowflake.FieldMap{
+			snowflake.FieldWorkerID:  snowflake.FieldDatacenterID,
+			snowflake.FieldProcessID: snowflake.FieldWorkerID,
+			snowflake.FieldSequence:  snowflake.FieldSequence,
+		})
+	if err != nil || s != 499677351741075456 || s.WorkerID() != 10 || s.ProcessID() != 27 {
+		t.Errorf("FAIL TestConvertExample: got %d (worker %d, process %d), %v",
+			s, s.WorkerID(), s.ProcessID(), err)
+	}
+}
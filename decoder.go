@@ -0,0 +1,182 @@
+package snowflake
+
+import "time"
+
+// Decoder of snowflake IDs with the Discord layout bound to the Discord epoch. Same as the
+// methods of [Snowflake] as long as [Epoch] is not changed.
+var DiscordDecoder = Decoder{Epoch: DiscordEpoch}
+
+// Decoder of snowflake IDs with the Discord layout ([LayoutDiscord]) and its own epoch. Unlike
+// the methods of [Snowflake] and [ParseTime], a decoder does not read the package-level [Epoch],
+// so IDs with different epochs can be decoded in one process without changing globals.
+//
+// Decoders are plain values: they are safe for concurrent use and can be copied freely. For IDs
+// with other layouts use a [Layout], which carries its own epoch as well.
+//
+//	custom := snowflake.Decoder{Epoch: 1288834974657}
+//	fmt.Println(custom.Time(s), snowflake.DiscordDecoder.Time(s))
+type Decoder struct {
+	// A Unix timestamp in milliseconds used as the decoder epoch. Zero is the Unix epoch, not
+	// the Discord epoch; use [DiscordDecoder] for Discord snowflake IDs.
+	Epoch uint64
+
+	// Duration of one timestamp tick. If zero, one millisecond is used. A negative time unit is
+	// rejected by [Decoder.ParseTimeChecked], the only method that returns errors; the other
+	// methods can not report it, so they use one millisecond as well. [NewLayout] and
+	// [NewGenerator] reject a negative time unit.
+	TimeUnit time.Duration
+}
+
+// # Method UnixMilli(s) of Decoder
+//
+// Returns snowflake creation date and time as milliseconds in unix format, rounded down if the
+// time unit is shorter than a millisecond.
+//
+// Calculation formula:
+//
+//	(snowflake>>22)*decoder.TimeUnit/time.Millisecond + decoder.Epoch
+//
+// # Arguments
+//
+//   - s [Snowflake]: Snowflake ID.
+//
+// # Return
+//
+//   - uint64: Unix timestamp in milliseconds.
+//
+// (No errors and examples)
+func (d Decoder) UnixMilli(s Snowflake) uint64 {
+	return d.base().unixMilli(discord.Timestamp(s))
+}
+
+// # Method Unix(s) of Decoder
+//
+// Returns snowflake creation date and time as seconds in unix format.
+//
+// # Arguments
+//
+//   - s [Snowflake]: Snowflake ID.
+//
+// # Return
+//
+//   - uint64: Unix timestamp in seconds.
+//
+// (No errors and examples)
+func (d Decoder) Unix(s Snowflake) uint64 {
+	return d.UnixMilli(s) / 1_000
+}
+
+// # Method Time(s) of Decoder
+//
+// Returns snowflake creation date and time. Exact for any time unit.
+//
+// # Arguments
+//
+//   - s [Snowflake]: Snowflake ID.
+//
+// # Return
+//
+//   - [time.Time]: Snowflake creation date and time.
+//
+// # Examples
+//
+//	s := snowflake.Snowflake(175928847299117063)
+//	fmt.Println(snowflake.DiscordDecoder.Time(s).UTC()) // 2016-04-30 11:18:25.796 +0000 UTC
+//
+// (No errors)
+func (d Decoder) Time(s Snowflake) time.Time {
+	return d.base().time(discord.Timestamp(s))
+}
+
+// # Method ParseTime(t) of Decoder
+//
+// Creates a new snowflake ID based on a [time.Time] with zero worker ID, process ID and sequence.
+// Same as [ParseTime], but uses the decoder epoch and time unit. Times before the epoch give a
+// zero timestamp and times after [Decoder.MaxTime] give the largest timestamp.
+//
+// # Arguments
+//
+//   - t [time.Time]: Time from which to parse a new snowflake ID.
+//
+// # Return
+//
+//   - [Snowflake]: New snowflake parsed from argument "t".
+//
+// (No errors and examples)
+func (d Decoder) ParseTime(t time.Time) Snowflake {
+	ts, err := d.base().ticks(t, MaxTimestamp)
+	switch err.(type) {
+	case *TimeBeforeEpochError:
+		ts = 0
+	case *TimeOverflowError:
+		ts = MaxTimestamp
+	}
+	return Snowflake(ts << 22)
+}
+
+// # Method ParseTimeChecked(t) of Decoder
+//
+// Creates a new snowflake ID based on a [time.Time] with zero worker ID, process ID and sequence.
+// Same as [Decoder.ParseTime], but returns an error instead of clamping out-of-range times.
+//
+// # Arguments
+//
+//   - t [time.Time]: Time from which to parse a new snowflake ID.
+//
+// # Return
+//
+//   - [Snowflake]: New snowflake parsed from argument "t".
+//   - error
+//
+// # Errors
+//
+//   - [SnowflakeError]: If the time unit of the decoder is negative.
+//   - [TimeBeforeEpochError]: If t is before [Decoder.MinTime].
+//   - [TimeOverflowError]: If t is in a time unit after the one that starts at
+//     [Decoder.MaxTime].
+//
+// (No examples)
+func (d Decoder) ParseTimeChecked(t time.Time) (Snowflake, error) {
+	if _, err := timeUnit(d.TimeUnit); err != nil {
+		return 0, err
+	}
+	ts, err := d.base().ticks(t, MaxTimestamp)
+	if err != nil {
+		return 0, err
+	}
+	return Snowflake(ts << 22), nil
+}
+
+// # Method MinTime() of Decoder
+//
+// Returns creation date and time of snowflake IDs with timestamp zero: the decoder epoch.
+//
+// # Return
+//
+//   - [time.Time]: Earliest representable date and time.
+//
+// (No arguments, errors, and examples)
+func (d Decoder) MinTime() time.Time {
+	return d.base().time(0)
+}
+
+// # Method MaxTime() of Decoder
+//
+// Returns creation date and time of snowflake IDs with the largest timestamp (42 bits).
+//
+// # Return
+//
+//   - [time.Time]: Largest representable date and time.
+//
+// (No arguments, errors, and examples)
+func (d Decoder) MaxTime() time.Time {
+	return d.base().time(MaxTimestamp)
+}
+
+func (d Decoder) base() timeBase {
+	unit := d.TimeUnit
+	if unit <= 0 {
+		unit = time.Millisecond
+	}
+	return timeBase{epoch: d.Epoch, unit: unit}
+}
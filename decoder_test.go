@@ -0,0 +1,62 @@
+package snowflake_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gophercord/snowflake"
+)
+
+func TestDecoder(t *testing.T) {
+	tests := []struct {
+		decoder snowflake.Decoder
+		id      snowflake.Snowflake
+		want    time.Time
+	}{
+		{snowflake.DiscordDecoder, 175928847299117063,
+			time.Date(2016, 4, 30, 11, 18, 25, 796e6, time.UTC)},
+		{snowflake.Decoder{}, 1 << 22, time.UnixMilli(1)},
+		{snowflake.Decoder{Epoch: 1288834974657}, 0, time.UnixMilli(1288834974657)},
+	}
+
+	for i, test := range tests {
+		if got := test.decoder.Time(test.id); !got.Equal(test.want) {
+			t.Errorf("FAIL TestDecoder[%d]: time %s, want %s", i, got, test.want)
+		}
+		if got := test.decoder.UnixMilli(test.id); got != uint64(test.want.UnixMilli()) {
+			t.Errorf("FAIL TestDecoder[%d]: unix milli %d, want %d",
+				i, got, test.want.UnixMilli())
+		}
+		if got := test.decoder.Unix(test.id); got != uint64(test.want.Unix()) {
+			t.Errorf("FAIL TestDecoder[%d]: unix %d, want %d", i, got, test.want.Unix())
+		}
+		if got := test.decoder.ParseTime(test.want); got != test.id>>22<<22 {
+			t.Errorf("FAIL TestDecoder[%d]: parsed %d, want %d", i, got, test.id>>22<<22)
+		}
+	}
+}
+
+// Decoders with different epochs must not interfere with each other.
+// Meaningful with -race.
+func TestDecoderConcurrent(t *testing.T) {
+	s := snowflake.Snowflake(175928847299117063)
+	want := snowflake.DiscordDecoder.Time(s)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(epoch uint64) {
+			defer wg.Done()
+			d := snowflake.Decoder{Epoch: epoch}
+			expected := uint64(want.UnixMilli()) - snowflake.DiscordEpoch + epoch
+			for j := 0; j < 1000; j++ {
+				if got := d.UnixMilli(s); got != expected {
+					t.Errorf("FAIL TestDecoderConcurrent: epoch %d got %d", epoch, got)
+					return
+				}
+			}
+		}(uint64(i) * 1_000_000)
+	}
+	wg.Wait()
+}
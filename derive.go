@@ -0,0 +1,197 @@
+package snowflake
+
+import (
+	"hash/fnv"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// # Function DeriveFromEnv(workerKey, processKey)
+//
+// Reads worker ID and process ID from environment variables.
+//
+// # Arguments
+//
+//   - workerKey string: Name of the environment variable with worker ID.
+//   - processKey string: Name of the environment variable with process ID. If empty, process ID
+//     is zero.
+//
+// # Return
+//
+//   - uint8: Worker ID (0-31).
+//   - uint8: Process ID (0-31).
+//   - error
+//
+// # Errors
+//
+//   - [DeriveError]: If a variable is not set or is not an integer.
+//   - [FieldOverflowError]: If a value is greater than 31.
+//
+// # Examples
+//
+//	// SNOWFLAKE_WORKER=3 SNOWFLAKE_PROCESS=7 ./mybot
+//	worker, process, err := snowflake.DeriveFromEnv("SNOWFLAKE_WORKER", "SNOWFLAKE_PROCESS")
+//	g, _ := snowflake.NewGenerator(snowflake.GeneratorConfig{
+//		WorkerID:  worker,
+//		ProcessID: process,
+//	})
+func DeriveFromEnv(workerKey, processKey string) (workerID, processID uint8, err error) {
+	if workerID, err = envID(workerKey, "worker ID", MaxWorkerID); err != nil {
+		return 0, 0, err
+	}
+	if processKey == "" {
+		return workerID, 0, nil
+	}
+	if processID, err = envID(processKey, "process ID", MaxProcessID); err != nil {
+		return 0, 0, err
+	}
+	return workerID, processID, nil
+}
+
+// # Function DeriveFromHostname()
+//
+// Derives worker ID and process ID from the FNV-1a hash of the hostname: worker ID is hash
+// bits 5-10, process ID is hash bits 0-5. The hostname is read from the HOSTNAME environment
+// variable (set by Kubernetes and most shells), or from [os.Hostname] if it is not set.
+//
+// Different hostnames may give the same pair, so use this strategy only when collisions are
+// acceptable or checked elsewhere.
+//
+// # Return
+//
+//   - uint8: Worker ID (0-31).
+//   - uint8: Process ID (0-31).
+//   - error
+//
+// # Errors
+//
+//   - [DeriveError]: If the hostname is unknown.
+//
+// (No arguments and examples)
+func DeriveFromHostname() (workerID, processID uint8, err error) {
+	hostname, err := deriveHostname()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(hostname))
+	sum := h.Sum32()
+	return uint8(sum >> 5 & MaxWorkerID), uint8(sum & MaxProcessID), nil
+}
+
+// # Function DeriveFromMAC()
+//
+// Derives worker ID and process ID from the last 10 bits of the hardware address of the first
+// network interface that is up and is not a loopback: worker ID is bits 5-10, process ID is
+// bits 0-5.
+//
+// # Return
+//
+//   - uint8: Worker ID (0-31).
+//   - uint8: Process ID (0-31).
+//   - error
+//
+// # Errors
+//
+//   - [DeriveError]: If there is no suitable network interface.
+//
+// (No arguments and examples)
+func DeriveFromMAC() (workerID, processID uint8, err error) {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return 0, 0, newDeriveError("unable to list network interfaces", err)
+	}
+
+	for _, i := range interfaces {
+		mac := i.HardwareAddr
+		if i.Flags&net.FlagLoopback != 0 || i.Flags&net.FlagUp == 0 || len(mac) < 2 {
+			continue
+		}
+		bits := uint16(mac[len(mac)-2])<<8 | uint16(mac[len(mac)-1])
+		return uint8(bits >> 5 & MaxWorkerID), uint8(bits & MaxProcessID), nil
+	}
+	return 0, 0, newDeriveError("no network interface with a hardware address", nil)
+}
+
+// # Function DeriveFromOrdinal(processID)
+//
+// Uses the ordinal at the end of the hostname as worker ID, as in Kubernetes StatefulSet pods
+// ("mybot-0", "mybot-1", ...). The hostname is read like in [DeriveFromHostname].
+//
+// # Arguments
+//
+//   - processID uint8: Process ID to return with the worker ID.
+//
+// # Return
+//
+//   - uint8: Worker ID (0-31).
+//   - uint8: Process ID (0-31).
+//   - error
+//
+// # Errors
+//
+//   - [DeriveError]: If the hostname is unknown or does not end with "-" and an ordinal.
+//   - [FieldOverflowError]: If the ordinal or process ID is greater than 31.
+//
+// # Examples
+//
+//	// HOSTNAME=mybot-4
+//	worker, process, _ := snowflake.DeriveFromOrdinal(1)
+//	fmt.Println(worker, process) // 4 1
+func DeriveFromOrdinal(processID uint8) (uint8, uint8, error) {
+	if processID > MaxProcessID {
+		return 0, 0, newFieldOverflowError("process ID", uint64(processID), MaxProcessID)
+	}
+
+	hostname, err := deriveHostname()
+	if err != nil {
+		return 0, 0, err
+	}
+	i := strings.LastIndexByte(hostname, '-')
+	if i < 0 {
+		return 0, 0, newDeriveError("hostname "+strconv.Quote(hostname)+" has no ordinal", nil)
+	}
+
+	ordinal, err := strconv.ParseUint(hostname[i+1:], 10, 64)
+	if err != nil {
+		return 0, 0, newDeriveError("hostname "+strconv.Quote(hostname)+" has no ordinal", err)
+	}
+	if ordinal > MaxWorkerID {
+		return 0, 0, newFieldOverflowError("ordinal", ordinal, MaxWorkerID)
+	}
+	return uint8(ordinal), processID, nil
+}
+
+func envID(key, field string, max uint64) (uint8, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return 0, newDeriveError("environment variable "+key+" is not set", nil)
+	}
+
+	id, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return 0, newDeriveError("environment variable "+key+" is not an integer", err)
+	}
+	if id > max {
+		return 0, newFieldOverflowError(field, id, max)
+	}
+	return uint8(id), nil
+}
+
+func deriveHostname() (string, error) {
+	if hostname := os.Getenv("HOSTNAME"); hostname != "" {
+		return hostname, nil
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return "", newDeriveError("unable to get hostname", err)
+	}
+	return hostname, nil
+}
+
+func newDeriveError(message string, err error) *DeriveError {
+	return &DeriveError{SnowflakeError: SnowflakeError{message: message, err: err}}
+}
@@ -0,0 +1,114 @@
+package snowflake_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gophercord/snowflake"
+)
+
+func TestDeriveFromEnv(t *testing.T) {
+	tests := []struct {
+		Worker, Process string
+		WantsWorker     uint8
+		WantsProcess    uint8
+		WantsErr        error
+	}{
+		{"3", "7", 3, 7, nil},
+		{" 31 ", "0", 31, 0, nil},
+		{"32", "0", 0, 0, &snowflake.FieldOverflowError{}},
+		{"0", "100", 0, 0, &snowflake.FieldOverflowError{}},
+		{"abc", "0", 0, 0, &snowflake.DeriveError{}},
+		{"-1", "0", 0, 0, &snowflake.DeriveError{}},
+	}
+
+	for i, test := range tests {
+		t.Setenv("TEST_SNOWFLAKE_WORKER", test.Worker)
+		t.Setenv("TEST_SNOWFLAKE_PROCESS", test.Process)
+
+		worker, process, err := snowflake.DeriveFromEnv("TEST_SNOWFLAKE_WORKER",
+			"TEST_SNOWFLAKE_PROCESS")
+
+		if !sameErrorType(err, test.WantsErr) {
+			t.Errorf("FAIL TestDeriveFromEnv[%d]: wanted error %T, got %v", i, test.WantsErr, err)
+		}
+		if worker != test.WantsWorker || process != test.WantsProcess {
+			t.Errorf("FAIL TestDeriveFromEnv[%d]: got (%d, %d), wanted (%d, %d)",
+				i, worker, process, test.WantsWorker, test.WantsProcess)
+		}
+	}
+
+	if _, _, err := snowflake.DeriveFromEnv("TEST_SNOWFLAKE_UNSET", ""); err == nil {
+		t.Errorf("FAIL TestDeriveFromEnv: unset variable wanted error!=nil but error IS nil")
+	}
+}
+
+func TestDeriveFromHostname(t *testing.T) {
+	t.Setenv("HOSTNAME", "bot-host-a")
+	worker, process, err := snowflake.DeriveFromHostname()
+	if err != nil || worker > snowflake.MaxWorkerID || process > snowflake.MaxProcessID {
+		t.Fatalf("FAIL TestDeriveFromHostname: got (%d, %d), %v", worker, process, err)
+	}
+
+	worker2, process2, _ := snowflake.DeriveFromHostname()
+	if worker != worker2 || process != process2 {
+		t.Errorf("FAIL TestDeriveFromHostname: same hostname gave different pairs")
+	}
+
+	t.Setenv("HOSTNAME", "bot-host-b")
+	worker3, process3, _ := snowflake.DeriveFromHostname()
+	if worker == worker3 && process == process3 {
+		t.Errorf("FAIL TestDeriveFromHostname: different hostnames gave the same pair")
+	}
+}
+
+func TestDeriveFromOrdinal(t *testing.T) {
+	tests := []struct {
+		Hostname    string
+		ProcessID   uint8
+		WantsWorker uint8
+		WantsErr    error
+	}{
+		{"mybot-0", 1, 0, nil},
+		{"my-bot-17", 2, 17, nil},
+		{"mybot-31", 0, 31, nil},
+		{"mybot-32", 0, 0, &snowflake.FieldOverflowError{}},
+		{"mybot-4", 32, 0, &snowflake.FieldOverflowError{}},
+		{"mybot", 0, 0, &snowflake.DeriveError{}},
+		{"mybot-x", 0, 0, &snowflake.DeriveError{}},
+	}
+
+	for i, test := range tests {
+		t.Setenv("HOSTNAME", test.Hostname)
+		worker, process, err := snowflake.DeriveFromOrdinal(test.ProcessID)
+
+		if !sameErrorType(err, test.WantsErr) {
+			t.Errorf("FAIL TestDeriveFromOrdinal[%d]: wanted error %T, got %v",
+				i, test.WantsErr, err)
+		}
+		if err == nil && (worker != test.WantsWorker || process != test.ProcessID) {
+			t.Errorf("FAIL TestDeriveFromOrdinal[%d]: got (%d, %d), wanted (%d, %d)",
+				i, worker, process, test.WantsWorker, test.ProcessID)
+		}
+	}
+}
+
+func TestDeriveFromMAC(t *testing.T) {
+	worker, process, err := snowflake.DeriveFromMAC()
+	var deriveErr *snowflake.DeriveError
+	if errors.As(err, &deriveErr) {
+		t.Skipf("SKIP TestDeriveFromMAC: no suitable network interface: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("FAIL TestDeriveFromMAC: DeriveFromMAC returned error %v", err)
+	}
+
+	if worker > snowflake.MaxWorkerID || process > snowflake.MaxProcessID {
+		t.Errorf("FAIL TestDeriveFromMAC: got (%d, %d), wanted both in 0-31", worker, process)
+	}
+	worker2, process2, _ := snowflake.DeriveFromMAC()
+	if worker2 != worker || process2 != process {
+		t.Errorf("FAIL TestDeriveFromMAC: got (%d, %d) and then (%d, %d), wanted the same pair",
+			worker, process, worker2, process2)
+	}
+}
@@ -0,0 +1,187 @@
+package snowflake
+
+import (
+	"sort"
+	"time"
+)
+
+// Default value of [Detector.MaxFuture].
+const DefaultDetectMaxFuture = time.Minute
+
+// Known snowflake schemes used by [Detect], in the order used to break ties.
+//
+// The dates are the launches of the schemes: the public launch of Discord and Mastodon, and the
+// epochs of Twitter, Instagram and Sonyflake, which started issuing IDs right after them.
+//
+// The typical field ranges are heuristics, not limits of the schemes: sequences restart at zero
+// every tick, so high values are rare, and Discord and Instagram use only a part of their worker
+// IDs and shard IDs. Twitter machine IDs, Sonyflake machine IDs (derived from IP addresses),
+// Instagram sequences and Mastodon random bits use their full range.
+//
+// The weights break ties between schemes that decode an ID equally well. Discord and Twitter
+// IDs have the same structure below the timestamp, and a Discord ID read as a Twitter one is
+// usually dated within the lifetime of Twitter, so the weights prefer the schemes this package
+// is mostly used with.
+var DefaultProfiles = []Profile{
+	{
+		Layout:  LayoutDiscord,
+		Since:   time.Date(2015, time.May, 13, 0, 0, 0, 0, time.UTC),
+		Typical: map[string]uint64{FieldWorkerID: 3, FieldSequence: 1023},
+	},
+	{
+		Layout:  LayoutTwitter,
+		Since:   time.Date(2010, time.November, 4, 0, 0, 0, 0, time.UTC),
+		Typical: map[string]uint64{FieldSequence: 1023},
+		Weight:  0.9,
+	},
+	{Layout: LayoutMastodon, Since: time.Date(2016, time.March, 1, 0, 0, 0, 0, time.UTC)},
+	{
+		Layout:  LayoutInstagram,
+		Since:   time.Date(2011, time.August, 24, 0, 0, 0, 0, time.UTC),
+		Typical: map[string]uint64{FieldShardID: 1999},
+		Weight:  0.8,
+	},
+	{
+		Layout:  LayoutSonyflake,
+		Since:   time.Date(2014, time.September, 1, 0, 0, 0, 0, time.UTC),
+		Typical: map[string]uint64{FieldSequence: 63},
+		Weight:  0.7,
+	},
+}
+
+// What [Detector] knows about one snowflake scheme.
+type Profile struct {
+	// Layout of the scheme.
+	Layout *Layout
+
+	// Earliest plausible creation time, for example the launch of the service. If zero, the
+	// layout epoch is used.
+	Since time.Time
+
+	// Largest typical values of some fields, by field name. Larger values make the candidate
+	// less plausible, but do not rule it out.
+	Typical map[string]uint64
+
+	// How common the scheme is compared to the others, from 0 (exclusive) to 1. The score of
+	// every candidate of the profile is multiplied by it. If zero, 1 is used.
+	Weight float64
+}
+
+// One possible interpretation of a snowflake ID, returned by [Detect].
+type Candidate struct {
+	Layout  *Layout   // Layout the ID was decoded with.
+	Time    time.Time // Creation time according to the layout.
+	Decoded Decoded   // Timestamp and field values according to the layout.
+
+	// Plausibility of the candidate, from 0 (exclusive) to 1. Starts at [Profile.Weight], is
+	// halved for every field above its typical value, and quartered if the time is before
+	// [Profile.Since].
+	Score float64
+}
+
+// Guesses the scheme of snowflake IDs from unknown sources. The zero value uses
+// [DefaultProfiles] and the system clock.
+type Detector struct {
+	// Known schemes. If nil, [DefaultProfiles] is used.
+	Profiles []Profile
+
+	// Source of the current time. If nil, [SystemClock] is used.
+	Clock Clock
+
+	// How far in the future a creation time may be before the candidate is ruled out, to allow
+	// for clock skew between the source of the ID and this process. If zero,
+	// [DefaultDetectMaxFuture] is used.
+	MaxFuture time.Duration
+}
+
+// # Function Detect(id)
+//
+// Tries every known snowflake scheme and returns the plausible ones, from the most to the least
+// plausible. Same as [Detector.Detect] of the zero [Detector].
+//
+// # Arguments
+//
+//   - id [Snowflake]: Snowflake ID of unknown origin.
+//
+// # Return
+//
+//   - [][Candidate]: Plausible interpretations of the ID, best first.
+//
+// # Examples
+//
+//	for _, c := range snowflake.Detect(1050118621198921728) {
+//		fmt.Println(c.Layout.Name(), c.Time.UTC(), c.Score)
+//	}
+//	// twitter 2018-10-10 20:19:24.211 +0000 UTC 0.9
+//	// instagram 2015-08-12 18:25:16.498 +0000 UTC 0.8
+//	// discord 2022-12-07 18:36:29.554 +0000 UTC 0.5
+//
+// (No errors)
+func Detect(id Snowflake) []Candidate {
+	return Detector{}.Detect(id)
+}
+
+// # Method Detect(id) of Detector
+//
+// Tries every profile of the detector and returns the plausible interpretations of a snowflake
+// ID, ranked by score. Candidates with equal scores keep the order of the profiles.
+//
+// A profile is ruled out if the ID has bits set above the layout width, or if its creation time
+// is more than [Detector.MaxFuture] ahead of the current time. Note that Discord and Twitter
+// snowflake IDs have the same structure below the timestamp, so their candidates often differ
+// only by [Profile.Weight]; compare the creation times to tell them apart.
+//
+// # Arguments
+//
+//   - id [Snowflake]: Snowflake ID of unknown origin.
+//
+// # Return
+//
+//   - [][Candidate]: Plausible interpretations of the ID, best first.
+//
+// (No errors and examples)
+func (d Detector) Detect(id Snowflake) []Candidate {
+	profiles := d.Profiles
+	if profiles == nil {
+		profiles = DefaultProfiles
+	}
+	clock := d.Clock
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	maxFuture := d.MaxFuture
+	if maxFuture == 0 {
+		maxFuture = DefaultDetectMaxFuture
+	}
+	latest := clock.Now().Add(maxFuture)
+
+	candidates := make([]Candidate, 0, len(profiles))
+	for _, p := range profiles {
+		if !p.Layout.fits(id) {
+			continue
+		}
+		t := p.Layout.Time(id)
+		if t.After(latest) {
+			continue
+		}
+
+		c := Candidate{Layout: p.Layout, Time: t, Decoded: p.Layout.Decode(id), Score: 1}
+		if p.Weight > 0 {
+			c.Score = p.Weight
+		}
+		if !p.Since.IsZero() && t.Before(p.Since) {
+			c.Score /= 4
+		}
+		for i, f := range p.Layout.fields {
+			if max, ok := p.Typical[f.Name]; ok && c.Decoded.Fields[i] > max {
+				c.Score /= 2
+			}
+		}
+		candidates = append(candidates, c)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	return candidates
+}
@@ -0,0 +1,82 @@
+package snowflake_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gophercord/snowflake"
+)
+
+func TestDetect(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	detector := snowflake.Detector{Clock: snowflake.NewManualClock(now)}
+
+	tests := []struct {
+		id   snowflake.Snowflake
+		want string // Name of the best candidate.
+		time time.Time
+	}{
+		{175928847299117063, "discord", time.Date(2016, 4, 30, 11, 18, 25, 796e6, time.UTC)},
+		{1363292549053284505, "discord", time.Date(2025, 4, 19, 23, 17, 52, 445e6, time.UTC)},
+		{1050118621198921728, "twitter", time.Date(2018, 10, 10, 20, 19, 24, 211e6, time.UTC)},
+		{103270115826048975, "mastodon", time.Date(2019, 12, 8, 3, 48, 33, 849e6, time.UTC)},
+		{1949525278281554174, "instagram", time.Date(2019, 1, 4, 17, 5, 45, 106e6, time.UTC)},
+	}
+
+	for i, test := range tests {
+		candidates := detector.Detect(test.id)
+		if len(candidates) == 0 {
+			t.Errorf("FAIL TestDetect[%d]: no candidates for %d", i, test.id)
+			continue
+		}
+		best := candidates[0]
+		if best.Layout.Name() != test.want {
+			t.Errorf("FAIL TestDetect[%d]: best candidate %s, want %s",
+				i, best.Layout.Name(), test.want)
+		}
+		if !best.Time.Equal(test.time) {
+			t.Errorf("FAIL TestDetect[%d]: time %s, want %s", i, best.Time, test.time)
+		}
+	}
+}
+
+func TestDetectNoTie(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	detector := snowflake.Detector{Clock: snowflake.NewManualClock(now)}
+
+	// Example snowflake from the Discord developer documentation. It is plausible in every
+	// default profile, so only the weights rank it.
+	candidates := detector.Detect(175928847299117063)
+	if len(candidates) < 2 {
+		t.Fatalf("FAIL TestDetectNoTie: got %d candidates, want several", len(candidates))
+	}
+	if name := candidates[0].Layout.Name(); name != "discord" {
+		t.Errorf("FAIL TestDetectNoTie: best candidate %s, want discord", name)
+	}
+	if candidates[1].Score >= candidates[0].Score {
+		t.Errorf("FAIL TestDetectNoTie: %s ties with %s at score %g",
+			candidates[1].Layout.Name(), candidates[0].Layout.Name(), candidates[0].Score)
+	}
+}
+
+func TestDetectRuledOut(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	detector := snowflake.Detector{
+		Profiles: []snowflake.Profile{{Layout: snowflake.LayoutTwitter}},
+		Clock:    snowflake.NewManualClock(now),
+	}
+
+	// The highest bit is not part of the Twitter layout.
+	if c := detector.Detect(1 << 63); len(c) != 0 {
+		t.Errorf("FAIL TestDetectRuledOut: got %d candidates for the highest bit", len(c))
+	}
+
+	future := snowflake.Snowflake((now.UnixMilli() - 1288834974657 + 2*60_000) << 22)
+	if c := detector.Detect(future); len(c) != 0 {
+		t.Errorf("FAIL TestDetectRuledOut: got %d candidates for a future ID", len(c))
+	}
+	detector.MaxFuture = time.Hour
+	if c := detector.Detect(future); len(c) != 1 || c[0].Score != 1 {
+		t.Errorf("FAIL TestDetectRuledOut: got %v with an hour of clock skew allowed", c)
+	}
+}
@@ -1,6 +1,9 @@
 package snowflake
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // Base error struct for all other snowflake errors. Implements error interface.
 type SnowflakeError struct {
@@ -10,6 +13,9 @@ type SnowflakeError struct {
 
 // Required to implement error interface. Returns formatted error.
 func (s SnowflakeError) Error() string {
+	if s.err == nil {
+		return s.message
+	}
 	return fmt.Sprintf("%s (original error: %s)", s.message, s.err.Error())
 }
 
@@ -27,3 +33,117 @@ type StringParseError struct{ SnowflakeError }
 //
 // [ParseJSON] When JSON is a unquoted integer and unquoted integers are not allowed.
 type UnquotedIntegerError struct{ SnowflakeError }
+
+// Used in:
+//
+// [NewGenerator] When worker ID or process ID is greater than 31.
+//
+// [Layout.Encode] and [Convert] When a value does not fit into its field.
+//
+// [Convert] When the snowflake ID does not fit into the source layout.
+//
+// [Compose], [Snowflake.WithWorkerID], [Snowflake.WithProcessID] and [Snowflake.WithSequence]
+// When a field is out of range.
+type FieldOverflowError struct {
+	SnowflakeError
+
+	Field string // Name of the field, for example "worker ID".
+	Value uint64 // Value that does not fit into the field.
+	Max   uint64 // Maximum value the field can hold.
+}
+
+// Used in:
+//
+// [Generator.Generate] When the current time is before the generator epoch.
+//
+// [Layout.TimestampOf] and [Convert] When the time is before the layout epoch.
+//
+// [Snowflake.WithTime], [ParseTimeChecked] and [Decoder.ParseTimeChecked] When the time is
+// before the epoch.
+//
+// [Snowflake.AddTime] When the new creation time would be before the epoch.
+type TimeBeforeEpochError struct{ SnowflakeError }
+
+// Used in:
+//
+// [Generator.Generate] When the number of time units since the generator epoch does not fit
+// into 42 bits.
+//
+// [Layout.TimestampOf] and [Convert] When the number of time units since the layout epoch does
+// not fit into the layout timestamp.
+//
+// [Snowflake.WithTime], [ParseTimeChecked] and [Decoder.ParseTimeChecked] When the timestamp
+// does not fit into 42 bits.
+//
+// [Snowflake.AddTime] When the new timestamp does not fit into 42 bits.
+type TimeOverflowError struct{ SnowflakeError }
+
+func newFieldOverflowError(field string, value, max uint64) *FieldOverflowError {
+	return &FieldOverflowError{
+		SnowflakeError: SnowflakeError{
+			message: fmt.Sprintf("%s %d is out of range 0-%d", field, value, max),
+		},
+		Field: field,
+		Value: value,
+		Max:   max,
+	}
+}
+
+// Used in:
+//
+// [Generator.Generate] When the system clock moved backwards and the generator uses
+// [ClockPolicyError], or uses [ClockPolicyLogical] and the logical clock would run ahead of the
+// system clock by more than the configured maximum drift.
+type ClockMovedBackwardsError struct {
+	SnowflakeError
+
+	Last  time.Time     // Time of the last generated snowflake ID.
+	Now   time.Time     // Current system time.
+	Drift time.Duration // How far the system clock is behind the last generated ID.
+}
+
+// Used in:
+//
+// [Generator.Generate] When all sequence numbers of the current time unit are used and the
+// generator uses [ExhaustionStrategyFail]. Always returned as [ErrSequenceExhausted].
+type SequenceExhaustedError struct{ SnowflakeError }
+
+// Returned by [Generator.Generate] when all sequence numbers of the current time unit are used
+// and the generator uses [ExhaustionStrategyFail]. Check for it with errors.Is.
+var ErrSequenceExhausted error = &SequenceExhaustedError{SnowflakeError: SnowflakeError{
+	message: "all sequence numbers of the current time unit are used",
+}}
+
+// Used in:
+//
+// [AcquireLease] When no free worker ID and process ID pair is left in the lease directory, or
+// the lease file can not be created.
+//
+// [Lease.Err] When the lease was lost.
+type LeaseError struct{ SnowflakeError }
+
+// Used in:
+//
+// [DeriveFromEnv], [DeriveFromHostname], [DeriveFromMAC] and [DeriveFromOrdinal] When the
+// source of worker ID and process ID is missing or malformed.
+type DeriveError struct{ SnowflakeError }
+
+// Used in:
+//
+// [NewGenerator] When the checkpoint file can not be read or is malformed.
+//
+// [Generator.Close] When the checkpoint file can not be written.
+//
+// [Generator.Generate] When a new checkpoint is needed before the snowflake ID can be returned,
+// and it can not be written.
+type CheckpointError struct{ SnowflakeError }
+
+// Used in:
+//
+// [NewLayout] When the layout has no timestamp, more than 64 bits, a negative time unit, or
+// fields without names, with duplicate names or with zero width.
+//
+// [Layout.Encode] When the number of field values does not match the layout.
+//
+// [Convert] When the field map names a field that does not exist.
+type LayoutError struct{ SnowflakeError }
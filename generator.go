@@ -0,0 +1,724 @@
+package snowflake
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Configuration of a [Generator]. Passed to [NewGenerator].
+type GeneratorConfig struct {
+	WorkerID  uint8 // Internal worker ID written into every generated snowflake (0-31).
+	ProcessID uint8 // Internal process ID written into every generated snowflake (0-31).
+
+	// A Unix timestamp in milliseconds used as the generator epoch. If zero, the value of
+	// [Epoch] at the moment of calling [NewGenerator] is used.
+	Epoch uint64
+
+	// Duration of one timestamp tick. The generator issues at most 4096 snowflake IDs per tick
+	// and the 42-bit timestamp covers 2^42 ticks, see [Generator.MaxTime]. If zero, one
+	// millisecond is used.
+	TimeUnit time.Duration
+
+	// Source of the current time. If nil, [SystemClock] is used.
+	Clock Clock
+
+	// What to do when the system clock moves backwards. By default [ClockPolicyWait].
+	ClockPolicy ClockPolicy
+
+	// How far the logical clock may run ahead of the system clock when ClockPolicy is
+	// [ClockPolicyLogical]. Rounded down to whole time units. If zero, [DefaultMaxClockDrift] is
+	// used.
+	MaxClockDrift time.Duration
+
+	// What to do when all sequence numbers of the current time unit are used. By default
+	// [ExhaustionStrategySleep].
+	ExhaustionStrategy ExhaustionStrategy
+
+	// Path of a file to store the high-water timestamp of the generator in. If set, the
+	// generator reads the file on creation and does not issue snowflake IDs at or below the
+	// stored timestamp (if the clock is behind it, the [ClockPolicy] applies), which protects
+	// from duplicates when the service restarts after the clock moved backwards. Generators must
+	// not share a checkpoint file.
+	//
+	// The stored timestamp is reserved ahead: it is up to twice CheckpointInterval after the
+	// current time or the last snowflake ID, and no ID above it is returned until a new
+	// checkpoint is written (if writing fails, the generator returns [CheckpointError]). So a
+	// crash never loses issued IDs, but a generator restarted after a crash starts up to twice
+	// CheckpointInterval after the crash time. [Generator.Close] stores the exact timestamp of
+	// the last ID instead.
+	CheckpointPath string
+
+	// How often the checkpoint is written. If zero, [DefaultCheckpointInterval] is used. If
+	// negative, the checkpoint is not written periodically, only when a snowflake ID reaches the
+	// reservation of [DefaultCheckpointInterval] and by [Generator.Close].
+	CheckpointInterval time.Duration
+
+	// Callbacks for generator events. If nil, no callbacks are called.
+	Hooks Hooks
+}
+
+// Policy of a [Generator] for the case when the system clock moves backwards (for example,
+// because of an NTP step or a VM migration). Without a policy, the generator would reuse
+// timestamps and create duplicate snowflake IDs.
+type ClockPolicy uint8
+
+const (
+	// Block until the system clock catches up with the last generated snowflake ID. Default.
+	ClockPolicyWait ClockPolicy = iota
+
+	// Return [ClockMovedBackwardsError] until the system clock catches up with the last
+	// generated snowflake ID.
+	ClockPolicyError
+
+	// Keep generating snowflake IDs from a logical clock that continues from the last
+	// generated ID. The logical clock may run ahead of the system clock by at most
+	// [GeneratorConfig.MaxClockDrift]; beyond that [ClockMovedBackwardsError] is returned.
+	ClockPolicyLogical
+)
+
+// Default value of [GeneratorConfig.MaxClockDrift].
+const DefaultMaxClockDrift = 5 * time.Second
+
+// # Method String() of ClockPolicy
+//
+// Returns name of the clock policy.
+//
+// # Return
+//
+//   - string: Clock policy name, for example "wait".
+//
+// (No arguments, errors, and examples)
+func (p ClockPolicy) String() string {
+	switch p {
+	case ClockPolicyWait:
+		return "wait"
+	case ClockPolicyError:
+		return "error"
+	case ClockPolicyLogical:
+		return "logical"
+	}
+	return "ClockPolicy(" + strconv.Itoa(int(p)) + ")"
+}
+
+// Strategy of a [Generator] for the case when all 4096 sequence numbers of the current time
+// unit are used.
+type ExhaustionStrategy uint8
+
+const (
+	// Sleep until the next time unit. Default.
+	ExhaustionStrategySleep ExhaustionStrategy = iota
+
+	// Busy-wait until the next time unit. Has lower latency than sleeping, but keeps the CPU
+	// busy while waiting. Never ends with a frozen [ManualClock], unless the context is done.
+	ExhaustionStrategySpin
+
+	// Return [ErrSequenceExhausted] immediately.
+	ExhaustionStrategyFail
+)
+
+// # Method String() of ExhaustionStrategy
+//
+// Returns name of the exhaustion strategy.
+//
+// # Return
+//
+//   - string: Exhaustion strategy name, for example "sleep".
+//
+// (No arguments, errors, and examples)
+func (e ExhaustionStrategy) String() string {
+	switch e {
+	case ExhaustionStrategySleep:
+		return "sleep"
+	case ExhaustionStrategySpin:
+		return "spin"
+	case ExhaustionStrategyFail:
+		return "fail"
+	}
+	return "ExhaustionStrategy(" + strconv.Itoa(int(e)) + ")"
+}
+
+// Information about the system clock moving backwards, passed to [Hooks.OnClockBackwards].
+type ClockRegression struct {
+	Previous time.Time     // System time observed by the previous call.
+	Now      time.Time     // Current system time.
+	Last     time.Time     // Time of the last generated snowflake ID.
+	Drift    time.Duration // How far the system clock moved backwards since the previous call.
+	Policy   ClockPolicy   // Policy the generator applies to this regression.
+}
+
+// Common interface of [Generator] and [AtomicGenerator].
+type IDGenerator interface {
+	// Generates a new snowflake ID. See [Generator.Generate].
+	Generate() (Snowflake, error)
+	// Generates a new snowflake ID, waiting at most until ctx is done. See [Generator.NextID].
+	NextID(ctx context.Context) (Snowflake, error)
+	// Reserves a block of n snowflake IDs. See [Generator.Reserve].
+	Reserve(ctx context.Context, n int) (Range, error)
+}
+
+// Generator of new snowflake IDs. Safe for concurrent use by multiple goroutines. State of the
+// generator is guarded by a mutex; see [AtomicGenerator] for a lock-free alternative.
+//
+// Every snowflake ID is built from the number of time units (milliseconds by default) since the
+// generator epoch, the configured worker ID and process ID, and a 12-bit sequence that is
+// incremented for every ID generated within the same time unit. IDs returned by one generator
+// are unique and strictly increasing.
+//
+// Create generators with [NewGenerator]; the zero value is not usable.
+type Generator struct {
+	generator
+
+	mu sync.Mutex
+	// Timestamp and sequence of the last generated ID, packed as timestamp<<12 | sequence.
+	last uint64
+	// Timestamp observed by the previous call, used to notice the clock moving backwards.
+	seen uint64
+}
+
+// # Function NewGenerator(config)
+//
+// Creates a new snowflake ID generator.
+//
+// # Arguments
+//
+//   - config [GeneratorConfig]: Generator configuration.
+//
+// # Return
+//
+//   - *[Generator]: New generator.
+//   - error
+//
+// # Errors
+//
+//   - [FieldOverflowError]: If worker ID is greater than [MaxWorkerID] or process ID is
+//     greater than [MaxProcessID].
+//   - [SnowflakeError]: If the clock policy or the exhaustion strategy is unknown, or the time
+//     unit is negative.
+//   - [CheckpointError]: If the checkpoint file can not be read, is malformed or out of range.
+//
+// # Examples
+//
+//	g, err := snowflake.NewGenerator(snowflake.GeneratorConfig{WorkerID: 1, ProcessID: 2})
+//	if err != nil {
+//		panic(err)
+//	}
+//	s, _ := g.Generate()
+//	fmt.Println(s.WorkerID(), s.ProcessID()) // 1 2
+func NewGenerator(config GeneratorConfig) (*Generator, error) {
+	core, err := newGenerator(config)
+	if err != nil {
+		return nil, err
+	}
+	last, err := core.restore()
+	if err != nil {
+		return nil, err
+	}
+
+	g := &Generator{generator: core, last: last}
+	g.startCheckpoints(func() uint64 {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return g.last
+	})
+	return g, nil
+}
+
+// # Wrapper for NewGenerator(config)
+//
+// Wrapper for [NewGenerator] function. Creates panic if [NewGenerator] returns an error.
+func MustNewGenerator(config GeneratorConfig) *Generator {
+	g, err := NewGenerator(config)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// # Method Generate() of Generator
+//
+// Generates a new snowflake ID. Same as [Generator.NextID] with [context.Background].
+//
+// # Return
+//
+//   - [Snowflake]: New unique snowflake ID.
+//   - error
+//
+// # Errors
+//
+//   - [TimeBeforeEpochError]: If the current time is before the generator epoch.
+//   - [TimeOverflowError]: If the number of time units since the generator epoch does not fit
+//     into 42 bits.
+//   - [ClockMovedBackwardsError]: If the system clock moved backwards and the clock policy
+//     does not allow to continue.
+//   - [ErrSequenceExhausted]: If the sequence of the current time unit is exhausted and the
+//     generator uses [ExhaustionStrategyFail].
+//   - [CheckpointError]: If the generator has a checkpoint path and a new checkpoint can not be
+//     written.
+//
+// # Examples
+//
+//	g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{WorkerID: 1})
+//	a, _ := g.Generate()
+//	b, _ := g.Generate()
+//	fmt.Println(a < b) // true
+func (g *Generator) Generate() (Snowflake, error) {
+	return g.NextID(context.Background())
+}
+
+// # Method NextID(ctx) of Generator
+//
+// Generates a new snowflake ID. If all 4096 sequence numbers of the current time unit are
+// used, the generator applies its [ExhaustionStrategy]. If the system clock moved backwards,
+// the generator applies its [ClockPolicy]. While waiting, the generator is not locked and the
+// call returns as soon as ctx is done.
+//
+// # Arguments
+//
+//   - ctx [context.Context]: Context that limits how long the call may wait.
+//
+// # Return
+//
+//   - [Snowflake]: New unique snowflake ID.
+//   - error
+//
+// # Errors
+//
+//   - Error of ctx ([context.Canceled] or [context.DeadlineExceeded]): If ctx is done before
+//     a snowflake ID is generated.
+//   - Same errors as [Generator.Generate].
+//
+// # Examples
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+//	defer cancel()
+//	s, err := g.NextID(ctx)
+//	if errors.Is(err, context.DeadlineExceeded) {
+//		// the generator had to wait too long
+//	}
+func (g *Generator) NextID(ctx context.Context) (Snowflake, error) {
+	state, err := g.run(ctx, 1, 0, g.step)
+	if err != nil {
+		return 0, err
+	}
+	return g.snowflake(state), nil
+}
+
+// # Method Reserve(ctx, n) of Generator
+//
+// Atomically reserves a block of n snowflake IDs that directly follow each other: no other call
+// gets an ID between the first and the last ID of the block. A block larger than 4096 IDs spans
+// several time units: it takes unused sequence numbers of the time units that passed while the
+// call was running, and if there are not enough of them, the call waits until the clock reaches
+// the last time unit of the block (using the [ExhaustionStrategy]; with
+// [ExhaustionStrategyFail] it returns [ErrSequenceExhausted] instead). The block never starts
+// before the time unit in which Reserve was called, so every ID of it is at least
+// [MinForTime] of the time of the call: a block of 10 000 IDs reserved by an idle generator
+// takes about 2 time units.
+//
+// # Arguments
+//
+//   - ctx [context.Context]: Context that limits how long the call may wait.
+//   - n int: Number of snowflake IDs to reserve.
+//
+// # Return
+//
+//   - [Range]: Reserved block of snowflake IDs.
+//   - error
+//
+// # Errors
+//
+//   - [SnowflakeError]: If n is not positive.
+//   - Same errors as [Generator.NextID].
+//
+// # Examples
+//
+//	r, _ := g.Reserve(context.Background(), 10_000)
+//	for i := 0; i < r.Len(); i++ {
+//		fmt.Println(r.At(i))
+//	}
+func (g *Generator) Reserve(ctx context.Context, n int) (Range, error) {
+	return g.reserve(ctx, n, g.step)
+}
+
+// # Method GenerateN(n) of Generator
+//
+// Generates n snowflake IDs at once. Same as [Generator.Reserve] with [context.Background],
+// converted to a slice.
+//
+// # Arguments
+//
+//   - n int: Number of snowflake IDs to generate.
+//
+// # Return
+//
+//   - []Snowflake: New unique snowflake IDs in increasing order.
+//   - error
+//
+// # Errors
+//
+//   - Same errors as [Generator.Reserve].
+//
+// (No examples)
+func (g *Generator) GenerateN(n int) ([]Snowflake, error) {
+	r, err := g.Reserve(context.Background(), n)
+	if err != nil {
+		return nil, err
+	}
+	return r.Slice(), nil
+}
+
+// # Wrapper for Generate()
+//
+// Wrapper for [Generator.Generate] method. Creates panic if [Generator.Generate] returns an
+// error.
+func (g *Generator) MustGenerate() Snowflake {
+	s, err := g.Generate()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Tries to move the generator n states forward. See generator.run and generator.advance.
+func (g *Generator) step(n, floor uint64, try bool) (
+	state, until uint64, exhausted bool, err error,
+) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	wall := g.clock.Now()
+	now, err := g.timestamp(wall)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	if now < g.seen {
+		g.regressed(g.seen, now, g.last, wall)
+	}
+	g.seen = now
+
+	state, until, exhausted, err = g.advance(g.last, now, n, floor, try, wall)
+	if err == nil && until == 0 {
+		g.last = state
+	}
+	return state, until, exhausted, err
+}
+
+// Settings and algorithm shared by [Generator] and [AtomicGenerator]. Immutable after creation.
+//
+// State of a generator is the timestamp and sequence of the last generated ID, packed into one
+// uint64 as timestamp<<12 | sequence.
+type generator struct {
+	base  timeBase
+	node  uint64 // Worker ID and process ID, already shifted into place.
+	clock Clock
+
+	clockPolicy   ClockPolicy
+	maxClockDrift uint64 // In time units.
+	exhaustion    ExhaustionStrategy
+	hooks         Hooks
+
+	checkpointPath     string
+	checkpointInterval time.Duration
+	checkpoint         *checkpointer // Nil if the generator has no checkpoint path.
+
+	stats *generatorStats
+}
+
+func newGenerator(config GeneratorConfig) (generator, error) {
+	if config.WorkerID > MaxWorkerID {
+		return generator{}, newFieldOverflowError("worker ID", uint64(config.WorkerID),
+			MaxWorkerID)
+	}
+	if config.ProcessID > MaxProcessID {
+		return generator{}, newFieldOverflowError("process ID", uint64(config.ProcessID),
+			MaxProcessID)
+	}
+	if config.ClockPolicy > ClockPolicyLogical {
+		return generator{}, &SnowflakeError{
+			message: "unknown clock policy " + config.ClockPolicy.String(),
+		}
+	}
+	if config.ExhaustionStrategy > ExhaustionStrategyFail {
+		return generator{}, &SnowflakeError{
+			message: "unknown exhaustion strategy " + config.ExhaustionStrategy.String(),
+		}
+	}
+	if config.Epoch == 0 {
+		config.Epoch = Epoch
+	}
+	unit, err := timeUnit(config.TimeUnit)
+	if err != nil {
+		return generator{}, err
+	}
+	if config.MaxClockDrift == 0 {
+		config.MaxClockDrift = DefaultMaxClockDrift
+	}
+	if config.Clock == nil {
+		config.Clock = SystemClock{}
+	}
+	if config.CheckpointInterval == 0 {
+		config.CheckpointInterval = DefaultCheckpointInterval
+	}
+
+	return generator{
+		base:          timeBase{epoch: config.Epoch, unit: unit},
+		node:          uint64(config.WorkerID)<<17 | uint64(config.ProcessID)<<12,
+		clock:         config.Clock,
+		clockPolicy:   config.ClockPolicy,
+		maxClockDrift: uint64(config.MaxClockDrift / unit),
+		exhaustion:    config.ExhaustionStrategy,
+		hooks:         config.Hooks,
+
+		checkpointPath:     config.CheckpointPath,
+		checkpointInterval: config.CheckpointInterval,
+
+		stats: &generatorStats{},
+	}, nil
+}
+
+// # Method ClockPolicy() of Generator
+//
+// Returns the policy the generator applies when the system clock moves backwards.
+//
+// # Return
+//
+//   - [ClockPolicy]: Clock policy.
+//
+// (No arguments, errors, and examples)
+func (g *generator) ClockPolicy() ClockPolicy {
+	return g.clockPolicy
+}
+
+// # Method MaxTime() of Generator
+//
+// Returns the date and time after which the generator can not issue snowflake IDs, because
+// the timestamp no longer fits into 42 bits.
+//
+// # Return
+//
+//   - [time.Time]: Largest representable date and time.
+//
+// (No arguments, errors, and examples)
+func (g *generator) MaxTime() time.Time {
+	return g.base.time(MaxTimestamp)
+}
+
+// Calls step until it moves the generator n states forward, and returns the last of these
+// states. No state of the block has a timestamp before floor. If step asks to wait (until is not
+// zero), waits until the system clock reaches timestamp until, using the exhaustion strategy if
+// the wait is caused by an exhausted sequence.
+func (g *generator) run(
+	ctx context.Context,
+	n, floor uint64,
+	step func(n, floor uint64, try bool) (state, until uint64, exhausted bool, err error),
+) (uint64, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		state, until, exhausted, err := step(n, floor, false)
+		if err != nil {
+			return 0, err
+		}
+		if until == 0 {
+			if err := g.covered(state); err != nil {
+				return 0, err
+			}
+			g.issued(state, n)
+			return state, nil
+		}
+
+		strategy := ExhaustionStrategySleep
+		if exhausted {
+			strategy = g.exhaustion
+		}
+		started := time.Now()
+		err = g.wait(ctx, until, strategy)
+		waited := time.Since(started)
+		g.stats.wait.Add(int64(waited))
+		if g.hooks != nil {
+			g.hooks.OnWait(Wait{
+				Until:     g.base.time(until),
+				Duration:  waited,
+				Exhausted: exhausted,
+				Err:       err,
+			})
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Returns state that ends a block of n states following state last at timestamp now (read from
+// the system clock at wall). For n = 1 the block is just the next state. The block does not
+// start before timestamp floor.
+//
+// If try is true, the caller does not wait for an exhausted sequence, so the exhaustion is not
+// recorded and the exhaustion strategy is not applied: the timestamp to wait for is returned.
+//
+// If the generator has to wait, returns the timestamp to wait for and whether the wait is caused
+// by an exhausted sequence (and not by the clock moving backwards).
+func (g *generator) advance(last, now, n, floor uint64, try bool, wall time.Time) (
+	state, until uint64, exhausted bool, err error,
+) {
+	ts := last >> 12
+	regressed := now < ts
+	if regressed {
+		if now, err = g.behind(last, now, wall); err != nil {
+			return 0, 0, false, err
+		}
+	}
+
+	first := last + 1
+	if now > ts {
+		// Start the block in the current time unit. If it does not fit, start it in one of
+		// the previous time units instead of waiting: their sequence numbers after the last
+		// state are unused. But never before floor (the time unit the call started in), so
+		// that no ID is older than the call that returned it.
+		start := now << 12
+		if n > MaxSequence+1 {
+			start = now<<12 | MaxSequence
+			if start >= n-1 {
+				start -= n - 1
+			} else {
+				start = 0
+			}
+			if start < floor<<12 {
+				start = floor << 12
+			}
+		}
+		if start > first {
+			first = start
+		}
+	}
+	// Sequence overflow carries into the timestamp, so a block may span several time units.
+	state = first + n - 1
+	if state>>12 > MaxTimestamp {
+		return 0, 0, false, &TimeOverflowError{SnowflakeError: SnowflakeError{
+			message: fmt.Sprintf("%d snowflake IDs do not fit before the end of time", n),
+		}}
+	}
+
+	switch {
+	case state>>12 <= now:
+		return state, 0, false, nil
+	case regressed && g.clockPolicy == ClockPolicyWait:
+		return 0, state >> 12, false, nil
+	case try:
+		return 0, state >> 12, true, nil
+	}
+
+	g.stats.exhaustions.Add(1)
+	if g.hooks != nil {
+		g.hooks.OnSequenceExhausted(g.base.time(now))
+	}
+	if g.exhaustion == ExhaustionStrategyFail {
+		return 0, 0, false, ErrSequenceExhausted
+	}
+	return 0, state >> 12, true, nil
+}
+
+// Applies the clock policy when the system clock (now) is behind the last generated ID. Returns
+// timestamp to continue with.
+func (g *generator) behind(last, now uint64, wall time.Time) (uint64, error) {
+	ts := last >> 12
+
+	switch g.clockPolicy {
+	case ClockPolicyError:
+		return 0, g.clockError(ts, now, wall)
+	case ClockPolicyLogical:
+		if ts-now > g.maxClockDrift {
+			return 0, g.clockError(ts, now, wall)
+		}
+		if last&MaxSequence == MaxSequence && ts+1-now <= g.maxClockDrift {
+			// Logical time unit is used up; move the logical clock one time unit further.
+			return ts + 1, nil
+		}
+		return ts, nil
+	}
+
+	// ClockPolicyWait: the caller waits for the next time unit after the last one.
+	return now, nil
+}
+
+func (g *generator) clockError(ts, now uint64, wall time.Time) error {
+	drift := g.base.duration(ts - now)
+
+	return &ClockMovedBackwardsError{
+		SnowflakeError: SnowflakeError{
+			message: fmt.Sprintf("clock moved backwards by %s (policy %s)", drift, g.clockPolicy),
+		},
+		Last:  g.base.time(ts),
+		Now:   wall,
+		Drift: drift,
+	}
+}
+
+// Reports that the system clock moved backwards from timestamp previous to timestamp now.
+func (g *generator) regressed(previous, now, last uint64, wall time.Time) {
+	g.stats.regressions.Add(1)
+	if g.hooks == nil {
+		return
+	}
+	g.hooks.OnClockBackwards(ClockRegression{
+		Previous: g.base.time(previous),
+		Now:      wall,
+		Last:     g.base.time(last >> 12),
+		Drift:    g.base.duration(previous - now),
+		Policy:   g.clockPolicy,
+	})
+}
+
+// Waits until the clock reaches timestamp until or ctx is done.
+func (g *generator) wait(ctx context.Context, until uint64, strategy ExhaustionStrategy) error {
+	deadline := g.base.time(until)
+
+	if strategy == ExhaustionStrategySpin {
+		for g.clock.Now().Before(deadline) {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			runtime.Gosched()
+		}
+		return nil
+	}
+
+	return g.clock.Sleep(ctx, deadline.Sub(g.clock.Now()))
+}
+
+// Reserves a block of n states with step and returns it as a range.
+func (g *generator) reserve(
+	ctx context.Context,
+	n int,
+	step func(n, floor uint64, try bool) (state, until uint64, exhausted bool, err error),
+) (Range, error) {
+	if n < 1 {
+		return Range{}, &SnowflakeError{message: "number of snowflake IDs must be positive"}
+	}
+
+	floor, err := g.timestamp(g.clock.Now())
+	if err != nil {
+		return Range{}, err
+	}
+	state, err := g.run(ctx, uint64(n), floor, step)
+	if err != nil {
+		return Range{}, err
+	}
+	return Range{node: g.node, first: state - uint64(n) + 1, n: uint64(n)}, nil
+}
+
+// Returns snowflake ID of the generator state.
+func (g *generator) snowflake(state uint64) Snowflake {
+	return Snowflake(state>>12<<22 | g.node | state&MaxSequence)
+}
+
+// Returns number of time units between the generator epoch and t.
+func (g *generator) timestamp(t time.Time) (uint64, error) {
+	return g.base.ticks(t, MaxTimestamp)
+}
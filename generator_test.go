@@ -0,0 +1,395 @@
+package snowflake_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gophercord/snowflake"
+)
+
+func TestNewGenerator(t *testing.T) {
+	tests := []struct {
+		Config   snowflake.GeneratorConfig
+		WantsErr bool
+	}{
+		{snowflake.GeneratorConfig{}, false},
+		{snowflake.GeneratorConfig{WorkerID: 31, ProcessID: 31}, false},
+		{snowflake.GeneratorConfig{WorkerID: 32}, true},
+		{snowflake.GeneratorConfig{ProcessID: 32}, true},
+		{snowflake.GeneratorConfig{WorkerID: 255, ProcessID: 255}, true},
+	}
+
+	for i, test := range tests {
+		_, err := snowflake.NewGenerator(test.Config)
+
+		if err == nil && test.WantsErr {
+			t.Errorf("FAIL TestNewGenerator[%d]: config<%+v> wanted error!=nil but error IS nil",
+				i, test.Config)
+		} else if err != nil && !test.WantsErr {
+			t.Errorf("FAIL TestNewGenerator[%d]: config<%+v> wanted error=nil but error is "+
+				"NOT nil (%v)",
+				i, test.Config, err)
+		}
+
+		var overflow *snowflake.FieldOverflowError
+		if err != nil && !errors.As(err, &overflow) {
+			t.Errorf("FAIL TestNewGenerator[%d]: wanted FieldOverflowError, got %T", i, err)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{WorkerID: 7, ProcessID: 19})
+
+	before := time.Now().Truncate(time.Millisecond)
+	var prev snowflake.Snowflake
+	for i := 0; i < 10_000; i++ {
+		s := g.MustGenerate()
+
+		if s <= prev {
+			t.Fatalf("FAIL TestGenerate[%d]: snowflake %d is not greater than previous %d",
+				i, s, prev)
+		}
+		if s.WorkerID() != 7 || s.ProcessID() != 19 {
+			t.Fatalf("FAIL TestGenerate[%d]: snowflake %d has worker ID %d and process ID %d, "+
+				"wanted 7 and 19",
+				i, s, s.WorkerID(), s.ProcessID())
+		}
+		prev = s
+	}
+
+	if prev.Time().Before(before) || prev.Time().After(time.Now()) {
+		t.Errorf("FAIL TestGenerate: snowflake time %s is outside of generation window",
+			prev.Time())
+	}
+}
+
+func TestGenerateConcurrent(t *testing.T) {
+	const goroutines, perGoroutine = 8, 5_000
+
+	g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{})
+	results := make([][]snowflake.Snowflake, goroutines)
+
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				results[i] = append(results[i], g.MustGenerate())
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[snowflake.Snowflake]bool, goroutines*perGoroutine)
+	for i, ids := range results {
+		for j, s := range ids {
+			if seen[s] {
+				t.Fatalf("FAIL TestGenerateConcurrent[%d]: duplicate snowflake %d", i, s)
+			}
+			if j > 0 && s <= ids[j-1] {
+				t.Fatalf("FAIL TestGenerateConcurrent[%d]: snowflake %d is not greater than "+
+					"previous %d",
+					i, s, ids[j-1])
+			}
+			seen[s] = true
+		}
+	}
+}
+
+func TestGenerateBeforeEpoch(t *testing.T) {
+	future := uint64(time.Now().Add(time.Hour).UnixMilli())
+	g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{Epoch: future})
+
+	_, err := g.Generate()
+
+	var beforeEpoch *snowflake.TimeBeforeEpochError
+	if !errors.As(err, &beforeEpoch) {
+		t.Errorf("FAIL TestGenerateBeforeEpoch: wanted TimeBeforeEpochError, got %v", err)
+	}
+}
+
+func TestClockPolicy(t *testing.T) {
+	tests := []struct {
+		Policy     snowflake.ClockPolicy
+		WantsName  string
+		WantsError bool
+	}{
+		{snowflake.ClockPolicyWait, "wait", false},
+		{snowflake.ClockPolicyError, "error", false},
+		{snowflake.ClockPolicyLogical, "logical", false},
+		{snowflake.ClockPolicy(42), "ClockPolicy(42)", true},
+	}
+
+	for i, test := range tests {
+		if name := test.Policy.String(); name != test.WantsName {
+			t.Errorf("FAIL TestClockPolicy[%d]: ClockPolicy<%d>.String() wanted %q, got %q",
+				i, test.Policy, test.WantsName, name)
+		}
+
+		g, err := snowflake.NewGenerator(snowflake.GeneratorConfig{ClockPolicy: test.Policy})
+		if (err != nil) != test.WantsError {
+			t.Errorf("FAIL TestClockPolicy[%d]: NewGenerator wanted error=%v, got %v",
+				i, test.WantsError, err)
+			continue
+		}
+		if err == nil && g.ClockPolicy() != test.Policy {
+			t.Errorf("FAIL TestClockPolicy[%d]: Generator.ClockPolicy() wanted %s, got %s",
+				i, test.Policy, g.ClockPolicy())
+		}
+	}
+}
+
+func TestExhaustionStrategy(t *testing.T) {
+	tests := []struct {
+		Strategy   snowflake.ExhaustionStrategy
+		WantsName  string
+		WantsError bool
+	}{
+		{snowflake.ExhaustionStrategySleep, "sleep", false},
+		{snowflake.ExhaustionStrategySpin, "spin", false},
+		{snowflake.ExhaustionStrategyFail, "fail", false},
+		{snowflake.ExhaustionStrategy(42), "ExhaustionStrategy(42)", true},
+	}
+
+	for i, test := range tests {
+		if name := test.Strategy.String(); name != test.WantsName {
+			t.Errorf("FAIL TestExhaustionStrategy[%d]: ExhaustionStrategy<%d>.String() wanted "+
+				"%q, got %q",
+				i, test.Strategy, test.WantsName, name)
+		}
+
+		g, err := snowflake.NewGenerator(snowflake.GeneratorConfig{
+			ExhaustionStrategy: test.Strategy,
+		})
+		if (err != nil) != test.WantsError {
+			t.Errorf("FAIL TestExhaustionStrategy[%d]: NewGenerator wanted error=%v, got %v",
+				i, test.WantsError, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		var prev snowflake.Snowflake
+		for j := 0; j < 3*(snowflake.MaxSequence+1); j++ {
+			s, err := g.Generate()
+			if errors.Is(err, snowflake.ErrSequenceExhausted) &&
+				test.Strategy == snowflake.ExhaustionStrategyFail {
+				continue
+			}
+			if err != nil {
+				t.Fatalf("FAIL TestExhaustionStrategy[%d]: Generate returned error %v", i, err)
+			}
+			if s <= prev {
+				t.Fatalf("FAIL TestExhaustionStrategy[%d]: snowflake %d is not greater than "+
+					"previous %d",
+					i, s, prev)
+			}
+			prev = s
+		}
+	}
+}
+
+func TestNextIDCanceled(t *testing.T) {
+	g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := g.NextID(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("FAIL TestNextIDCanceled: wanted context.Canceled, got %v", err)
+	}
+}
+
+func TestGenerateManualClock(t *testing.T) {
+	clock := snowflake.NewManualClock(start)
+	g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{
+		Clock:              clock,
+		ExhaustionStrategy: snowflake.ExhaustionStrategyFail,
+	})
+
+	for i := 0; i <= snowflake.MaxSequence; i++ {
+		s := g.MustGenerate()
+		if !s.Time().Equal(start) || s.Sequence() != uint16(i) {
+			t.Fatalf("FAIL TestGenerateManualClock[%d]: got snowflake at %s with sequence %d",
+				i, s.Time(), s.Sequence())
+		}
+	}
+
+	if _, err := g.Generate(); !errors.Is(err, snowflake.ErrSequenceExhausted) {
+		t.Fatalf("FAIL TestGenerateManualClock: wanted ErrSequenceExhausted, got %v", err)
+	}
+
+	clock.Advance(time.Millisecond)
+	if s, err := g.Generate(); err != nil || s.Sequence() != 0 {
+		t.Errorf("FAIL TestGenerateManualClock: after advancing got %d, %v", s, err)
+	}
+}
+
+func TestNextIDDeadline(t *testing.T) {
+	clock := snowflake.NewManualClock(start)
+	g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{Clock: clock})
+
+	if _, err := g.Reserve(context.Background(), snowflake.MaxSequence+1); err != nil {
+		t.Fatalf("FAIL TestNextIDDeadline: Reserve returned error %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := g.NextID(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("FAIL TestNextIDDeadline: wanted context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestClockRegression(t *testing.T) {
+	tests := []struct {
+		Policy     snowflake.ClockPolicy
+		Rewind     time.Duration
+		WantsError bool
+	}{
+		{snowflake.ClockPolicyError, time.Second, true},
+		{snowflake.ClockPolicyLogical, time.Second, false},
+		{snowflake.ClockPolicyLogical, time.Minute, true},
+	}
+
+	for i, test := range tests {
+		clock := snowflake.NewManualClock(start)
+		hooks := &recordingHooks{}
+
+		g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{
+			Clock:         clock,
+			ClockPolicy:   test.Policy,
+			MaxClockDrift: 10 * time.Second,
+			Hooks:         hooks,
+		})
+
+		first := g.MustGenerate()
+		clock.Advance(-test.Rewind)
+		s, err := g.Generate()
+
+		var backwards *snowflake.ClockMovedBackwardsError
+		if test.WantsError && !errors.As(err, &backwards) {
+			t.Errorf("FAIL TestClockRegression[%d]: wanted ClockMovedBackwardsError, got %v",
+				i, err)
+		}
+		if !test.WantsError && (err != nil || s <= first || !s.Time().Equal(first.Time())) {
+			t.Errorf("FAIL TestClockRegression[%d]: wanted snowflake after %d at %s, got %d, %v",
+				i, first, first.Time(), s, err)
+		}
+		if backwards != nil && backwards.Drift != test.Rewind {
+			t.Errorf("FAIL TestClockRegression[%d]: error drift %s, wanted %s",
+				i, backwards.Drift, test.Rewind)
+		}
+
+		events := hooks.backwards
+		if len(events) != 1 || events[0].Drift != test.Rewind || events[0].Policy != test.Policy {
+			t.Errorf("FAIL TestClockRegression[%d]: wanted one event with drift %s, got %+v",
+				i, test.Rewind, events)
+		}
+	}
+}
+
+func TestClockRegressionWait(t *testing.T) {
+	clock := snowflake.NewManualClock(start)
+	g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{Clock: clock})
+
+	first := g.MustGenerate()
+	clock.Advance(-time.Second)
+
+	done := make(chan snowflake.Snowflake)
+	go func() { done <- g.MustGenerate() }()
+
+	select {
+	case s := <-done:
+		t.Fatalf("FAIL TestClockRegressionWait: Generate returned %d before clock caught up", s)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clock.Advance(time.Second)
+	select {
+	case s := <-done:
+		if s <= first {
+			t.Errorf("FAIL TestClockRegressionWait: snowflake %d is not greater than %d",
+				s, first)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("FAIL TestClockRegressionWait: Generate did not return after clock caught up")
+	}
+}
+
+// Clock that stays where it is set and jumps forward when slept on, so waits end immediately.
+type steppedClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	slept time.Duration
+}
+
+func (c *steppedClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *steppedClock) Sleep(ctx context.Context, d time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if d > 0 {
+		c.now = c.now.Add(d)
+		c.slept += d
+	}
+	return ctx.Err()
+}
+
+func (c *steppedClock) set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+func TestClockPolicyBehavior(t *testing.T) {
+	tests := []struct {
+		Policy     snowflake.ClockPolicy
+		MaxDrift   time.Duration
+		WantsError bool
+		WantsSleep bool
+	}{
+		// Waits until the clock reaches the last ID, then continues in the same millisecond.
+		{snowflake.ClockPolicyWait, 0, false, true},
+		{snowflake.ClockPolicyError, 0, true, false},
+		// Continues from the last ID without waiting, while the drift is small enough.
+		{snowflake.ClockPolicyLogical, 10 * time.Second, false, false},
+		{snowflake.ClockPolicyLogical, 100 * time.Millisecond, true, false},
+	}
+
+	for i, test := range tests {
+		clock := &steppedClock{now: start}
+		g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{
+			Clock:         clock,
+			ClockPolicy:   test.Policy,
+			MaxClockDrift: test.MaxDrift,
+		})
+
+		first := g.MustGenerate()
+		clock.set(start.Add(-time.Second))
+		s, err := g.Generate()
+
+		var backwards *snowflake.ClockMovedBackwardsError
+		switch {
+		case test.WantsError && (!errors.As(err, &backwards) || backwards.Drift != time.Second):
+			t.Errorf("FAIL TestClockPolicyBehavior[%d]: wanted error with 1s drift, got %v",
+				i, err)
+		case !test.WantsError && (err != nil || s <= first || !s.Time().Equal(start)):
+			t.Errorf("FAIL TestClockPolicyBehavior[%d]: wanted snowflake after %d at %s, got "+
+				"%d, %v", i, first, start, s, err)
+		}
+		if slept := clock.slept > 0; slept != test.WantsSleep {
+			t.Errorf("FAIL TestClockPolicyBehavior[%d]: slept %s, wanted sleep=%v",
+				i, clock.slept, test.WantsSleep)
+		}
+	}
+}
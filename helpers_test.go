@@ -0,0 +1,37 @@
+package snowflake_test
+
+import (
+	"errors"
+
+	"github.com/gophercord/snowflake"
+)
+
+// Reports whether err matches the error type of want: err is nil if want is nil, or err (or an
+// error it wraps) has the same type as want. Supports the error types of the snowflake package
+// that the tests expect.
+func sameErrorType(err, want error) bool {
+	var overflow *snowflake.FieldOverflowError
+	var derive *snowflake.DeriveError
+	var before *snowflake.TimeBeforeEpochError
+	var overflowTime *snowflake.TimeOverflowError
+	var layout *snowflake.LayoutError
+	var backwards *snowflake.ClockMovedBackwardsError
+
+	switch want.(type) {
+	case nil:
+		return err == nil
+	case *snowflake.FieldOverflowError:
+		return errors.As(err, &overflow)
+	case *snowflake.DeriveError:
+		return errors.As(err, &derive)
+	case *snowflake.TimeBeforeEpochError:
+		return errors.As(err, &before)
+	case *snowflake.TimeOverflowError:
+		return errors.As(err, &overflowTime)
+	case *snowflake.LayoutError:
+		return errors.As(err, &layout)
+	case *snowflake.ClockMovedBackwardsError:
+		return errors.As(err, &backwards)
+	}
+	return false
+}
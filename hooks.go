@@ -0,0 +1,49 @@
+package snowflake
+
+import "time"
+
+// Callbacks for generator events, for example to log clock drift or to trace waits. Set with
+// [GeneratorConfig.Hooks].
+//
+// All methods are called synchronously by the goroutine that generates the snowflake ID.
+// OnClockBackwards and OnSequenceExhausted are called while the generator is locked, so they
+// must be fast and must not use the generator. Embed [NoopHooks] to implement only some of the
+// methods.
+type Hooks interface {
+	// Called after a snowflake ID (a range of one ID) or a reserved block is generated.
+	OnIssue(r Range)
+
+	// Called after a call waited for the clock, whether the wait succeeded or not.
+	OnWait(w Wait)
+
+	// Called when the generator notices that the clock moved backwards.
+	OnClockBackwards(r ClockRegression)
+
+	// Called when all sequence numbers of the time unit that starts at time t are used.
+	OnSequenceExhausted(t time.Time)
+}
+
+// Implementation of [Hooks] that does nothing. Embed it into your own type to implement only
+// the methods you need:
+//
+//	type driftLogger struct{ snowflake.NoopHooks }
+//
+//	func (driftLogger) OnClockBackwards(r snowflake.ClockRegression) {
+//		log.Printf("clock moved backwards by %s", r.Drift)
+//	}
+type NoopHooks struct{}
+
+func (NoopHooks) OnIssue(Range)                    {}
+func (NoopHooks) OnWait(Wait)                      {}
+func (NoopHooks) OnClockBackwards(ClockRegression) {}
+func (NoopHooks) OnSequenceExhausted(time.Time)    {}
+
+// Information about a wait for the clock, passed to [Hooks.OnWait].
+type Wait struct {
+	Until    time.Time     // Time the call waited for.
+	Duration time.Duration // How long the call waited.
+	// True if the wait was caused by an exhausted sequence, false if by the clock moving
+	// backwards.
+	Exhausted bool
+	Err       error // Error of the context if the wait was interrupted, otherwise nil.
+}
@@ -0,0 +1,95 @@
+package snowflake_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gophercord/snowflake"
+)
+
+// Hooks that record all events.
+type recordingHooks struct {
+	mu        sync.Mutex
+	issued    []snowflake.Range
+	waits     []snowflake.Wait
+	backwards []snowflake.ClockRegression
+	exhausted []time.Time
+}
+
+func (h *recordingHooks) OnIssue(r snowflake.Range) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.issued = append(h.issued, r)
+}
+
+func (h *recordingHooks) OnWait(w snowflake.Wait) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.waits = append(h.waits, w)
+}
+
+func (h *recordingHooks) OnClockBackwards(r snowflake.ClockRegression) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.backwards = append(h.backwards, r)
+}
+
+func (h *recordingHooks) OnSequenceExhausted(t time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.exhausted = append(h.exhausted, t)
+}
+
+func TestHooks(t *testing.T) {
+	clock := snowflake.NewManualClock(start)
+	hooks := &recordingHooks{}
+	g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{Clock: clock, Hooks: hooks})
+
+	s := g.MustGenerate()
+	r, _ := g.Reserve(context.Background(), snowflake.MaxSequence)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	if _, err := g.NextID(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("FAIL TestHooks: wanted context.DeadlineExceeded, got %v", err)
+	}
+
+	if len(hooks.issued) != 2 || hooks.issued[0].First() != s || hooks.issued[1] != r {
+		t.Errorf("FAIL TestHooks: OnIssue got %+v, wanted snowflake %d and range %+v",
+			hooks.issued, s, r)
+	}
+	if len(hooks.exhausted) != 1 || !hooks.exhausted[0].Equal(start) {
+		t.Errorf("FAIL TestHooks: OnSequenceExhausted got %v, wanted %s", hooks.exhausted, start)
+	}
+
+	w := hooks.waits
+	if len(w) != 1 || !w[0].Exhausted || !errors.Is(w[0].Err, context.DeadlineExceeded) ||
+		!w[0].Until.Equal(start.Add(time.Millisecond)) || w[0].Duration <= 0 {
+		t.Errorf("FAIL TestHooks: OnWait got %+v", w)
+	}
+}
+
+// Hooks that implement only one method.
+type issueCounter struct {
+	snowflake.NoopHooks
+	count int
+}
+
+func (h *issueCounter) OnIssue(r snowflake.Range) {
+	h.count += r.Len()
+}
+
+func TestNoopHooks(t *testing.T) {
+	hooks := &issueCounter{}
+	g := snowflake.MustNewAtomicGenerator(snowflake.GeneratorConfig{Hooks: hooks})
+
+	g.MustGenerate()
+	g.GenerateN(10)
+
+	if hooks.count != 11 {
+		t.Errorf("FAIL TestNoopHooks: OnIssue counted %d IDs, wanted 11", hooks.count)
+	}
+}
@@ -0,0 +1,515 @@
+package snowflake
+
+import (
+	"fmt"
+	"time"
+)
+
+// Names of the fields of built-in layouts.
+const (
+	FieldWorkerID     = "worker ID"     // Worker ID of [LayoutDiscord] and [LayoutTwitterSplit].
+	FieldProcessID    = "process ID"    // Internal process ID of [LayoutDiscord].
+	FieldDatacenterID = "datacenter ID" // Datacenter ID of [LayoutTwitterSplit].
+	FieldSequence     = "sequence"      // Per-process counter of most built-in layouts.
+	FieldMachineID    = "machine ID"    // Machine ID of [LayoutTwitter] and [LayoutSonyflake].
+	FieldShardID      = "shard ID"      // Logical shard ID of [LayoutInstagram].
+	FieldRandom       = "random"        // Random bits of [LayoutMastodon].
+)
+
+// Discord snowflake layout: 42-bit timestamp in milliseconds since the Discord epoch, 5-bit worker
+// ID, 5-bit process ID and 12-bit sequence. Methods of [Snowflake] always decode this layout, but
+// use the value of [Epoch] instead of the layout epoch.
+var LayoutDiscord = discord
+
+// Layout used by methods of [Snowflake]. Kept separately, so reassigning [LayoutDiscord] does not
+// change them.
+var discord = MustNewLayout(LayoutConfig{
+	Name:          "discord",
+	TimestampBits: 42,
+	Epoch:         DiscordEpoch,
+	Fields: []Field{
+		{FieldWorkerID, 5},
+		{FieldProcessID, 5},
+		{FieldSequence, 12},
+	},
+})
+
+// Twitter/X snowflake layout: 41-bit timestamp in milliseconds since 2010-11-04 01:42:54.657 UTC,
+// 10-bit machine ID and 12-bit sequence. The highest bit is always zero. See
+// [LayoutTwitterSplit] for the machine ID split into datacenter ID and worker ID.
+var LayoutTwitter = MustNewLayout(LayoutConfig{
+	Name:          "twitter",
+	TimestampBits: 41,
+	Epoch:         1288834974657,
+	Fields:        []Field{{FieldMachineID, 10}, {FieldSequence, 12}},
+})
+
+// Same bits as [LayoutTwitter], with the machine ID split into 5-bit datacenter ID and 5-bit
+// worker ID, as in the original Twitter snowflake service. Both halves fit into the fields of
+// [LayoutDiscord], so [Convert] can map them to Discord worker ID and process ID.
+var LayoutTwitterSplit = MustNewLayout(LayoutConfig{
+	Name:          "twitter-split",
+	TimestampBits: 41,
+	Epoch:         1288834974657,
+	Fields: []Field{
+		{FieldDatacenterID, 5},
+		{FieldWorkerID, 5},
+		{FieldSequence, 12},
+	},
+})
+
+// Sonyflake layout with default settings: 39-bit timestamp in units of 10 milliseconds since
+// 2014-09-01 00:00:00 UTC, 8-bit sequence and 16-bit machine ID. Note that the sequence is stored
+// above the machine ID. The highest bit is always zero.
+var LayoutSonyflake = MustNewLayout(LayoutConfig{
+	Name:          "sonyflake",
+	TimestampBits: 39,
+	Epoch:         1409529600000,
+	TimeUnit:      10 * time.Millisecond,
+	Fields:        []Field{{FieldSequence, 8}, {FieldMachineID, 16}},
+})
+
+// Instagram layout: 41-bit timestamp in milliseconds since 2011-08-24 21:07:01.721 UTC, 13-bit
+// logical shard ID and 10-bit sequence.
+var LayoutInstagram = MustNewLayout(LayoutConfig{
+	Name:          "instagram",
+	TimestampBits: 41,
+	Epoch:         1314220021721,
+	Fields:        []Field{{FieldShardID, 13}, {FieldSequence, 10}},
+})
+
+// Mastodon layout: 48-bit timestamp in milliseconds since the Unix epoch and 16 random bits.
+var LayoutMastodon = MustNewLayout(LayoutConfig{
+	Name:          "mastodon",
+	TimestampBits: 48,
+	Fields:        []Field{{FieldRandom, 16}},
+})
+
+// One field of a [Layout], stored below the timestamp.
+type Field struct {
+	Name string // Name of the field, for example "worker ID". Must be unique within a layout.
+	Bits uint8  // Width of the field in bits.
+}
+
+// Configuration of a [Layout]. Passed to [NewLayout].
+type LayoutConfig struct {
+	// Name of the layout, for example "discord".
+	Name string
+
+	// Width of the timestamp in bits. The timestamp is stored above all fields.
+	TimestampBits uint8
+
+	// A Unix timestamp in milliseconds used as the layout epoch.
+	Epoch uint64
+
+	// Duration of one timestamp tick, for example 10*time.Millisecond. If zero, one millisecond
+	// is used.
+	TimeUnit time.Duration
+
+	// Fields stored below the timestamp, from the most significant to the least significant.
+	// Together with the timestamp they may take at most 64 bits; unused high bits must be zero.
+	Fields []Field
+}
+
+// Description of how a snowflake ID is split into a timestamp and named fields. Lets the package
+// decode and encode snowflake-like IDs of schemes other than Discord's, as long as they store a
+// timestamp in the most significant bits.
+//
+// Layouts are immutable and safe for concurrent use. Create layouts with [NewLayout]; the zero
+// value is not usable.
+type Layout struct {
+	name   string
+	base   timeBase
+	fields []layoutField
+
+	bits           uint8 // Total width of the timestamp and all fields.
+	timestampBits  uint8
+	timestampShift uint8
+	timestampMask  uint64
+}
+
+// Field of a layout with precomputed position.
+type layoutField struct {
+	Field
+
+	shift uint8
+	mask  uint64 // Not shifted.
+}
+
+// Values of a snowflake ID decoded with a [Layout].
+type Decoded struct {
+	Timestamp uint64   // Number of time units since the layout epoch.
+	Fields    []uint64 // Values of the layout fields, in the layout order.
+}
+
+// # Function NewLayout(config)
+//
+// Creates a new snowflake layout.
+//
+// # Arguments
+//
+//   - config [LayoutConfig]: Layout configuration.
+//
+// # Return
+//
+//   - *[Layout]: New layout.
+//   - error
+//
+// # Errors
+//
+//   - [LayoutError]: If the layout has no timestamp, takes more than 64 bits, has a negative
+//     time unit, or has fields without names, with duplicate names or with zero width.
+//
+// # Examples
+//
+//	layout := snowflake.MustNewLayout(snowflake.LayoutConfig{
+//		Name:          "mybot",
+//		TimestampBits: 41,
+//		Epoch:         1704067200000, // 2024-01-01 00:00:00 UTC
+//		Fields:        []snowflake.Field{{"node ID", 10}, {"sequence", 12}},
+//	})
+func NewLayout(config LayoutConfig) (*Layout, error) {
+	if config.TimestampBits == 0 {
+		return nil, newLayoutError("layout %q has no timestamp", config.Name)
+	}
+	unit, err := timeUnit(config.TimeUnit)
+	if err != nil {
+		return nil, newLayoutError("time unit %s of layout %q is negative", config.TimeUnit,
+			config.Name)
+	}
+
+	l := &Layout{
+		name:          config.Name,
+		base:          timeBase{epoch: config.Epoch, unit: unit},
+		fields:        make([]layoutField, len(config.Fields)),
+		timestampBits: config.TimestampBits,
+	}
+
+	total := uint(config.TimestampBits)
+	for _, f := range config.Fields {
+		total += uint(f.Bits)
+	}
+	if total > 64 {
+		return nil, newLayoutError("layout %q takes %d bits, more than 64", config.Name, total)
+	}
+
+	shift := uint8(0)
+	names := make(map[string]bool, len(config.Fields))
+	for i := len(config.Fields) - 1; i >= 0; i-- {
+		f := config.Fields[i]
+		switch {
+		case f.Name == "":
+			return nil, newLayoutError("layout %q has a field without name", config.Name)
+		case names[f.Name]:
+			return nil, newLayoutError("layout %q has duplicate field %q", config.Name, f.Name)
+		case f.Bits == 0:
+			return nil, newLayoutError("field %q of layout %q has zero width", f.Name, config.Name)
+		}
+		names[f.Name] = true
+		l.fields[i] = layoutField{Field: f, shift: shift, mask: mask(f.Bits)}
+		shift += f.Bits
+	}
+	l.bits = uint8(total)
+	l.timestampShift = shift
+	l.timestampMask = mask(config.TimestampBits)
+	return l, nil
+}
+
+// # Wrapper for NewLayout(config)
+//
+// Wrapper for [NewLayout] function. Creates panic if [NewLayout] returns an error.
+func MustNewLayout(config LayoutConfig) *Layout {
+	l, err := NewLayout(config)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// # Method Name() of Layout
+//
+// Returns the layout name.
+//
+// (No arguments, errors, and examples)
+func (l *Layout) Name() string {
+	return l.name
+}
+
+// # Method Epoch() of Layout
+//
+// Returns the layout epoch as a Unix timestamp in milliseconds.
+//
+// (No arguments, errors, and examples)
+func (l *Layout) Epoch() uint64 {
+	return l.base.epoch
+}
+
+// # Method TimeUnit() of Layout
+//
+// Returns the duration of one timestamp tick.
+//
+// (No arguments, errors, and examples)
+func (l *Layout) TimeUnit() time.Duration {
+	return l.base.unit
+}
+
+// # Method TimestampBits() of Layout
+//
+// Returns the width of the timestamp in bits.
+//
+// (No arguments, errors, and examples)
+func (l *Layout) TimestampBits() uint8 {
+	return l.timestampBits
+}
+
+// # Method MaxTimestamp() of Layout
+//
+// Returns the largest timestamp that fits into the layout.
+//
+// (No arguments, errors, and examples)
+func (l *Layout) MaxTimestamp() uint64 {
+	return l.timestampMask
+}
+
+// # Method MaxTime() of Layout
+//
+// Returns creation date and time of snowflake IDs with the largest timestamp that fits into the
+// layout.
+//
+// # Return
+//
+//   - [time.Time]: Largest representable date and time.
+//
+// # Examples
+//
+//	fmt.Println(snowflake.LayoutDiscord.MaxTime().UTC()) // 2154-05-15 07:35:11.103 +0000 UTC
+//
+// (No arguments and errors)
+func (l *Layout) MaxTime() time.Time {
+	return l.base.time(l.timestampMask)
+}
+
+// # Method Fields() of Layout
+//
+// Returns the layout fields, from the most significant to the least significant.
+//
+// # Return
+//
+//   - [][Field]: Copy of the layout fields.
+//
+// (No arguments, errors, and examples)
+func (l *Layout) Fields() []Field {
+	fields := make([]Field, len(l.fields))
+	for i, f := range l.fields {
+		fields[i] = f.Field
+	}
+	return fields
+}
+
+// # Method Timestamp(s) of Layout
+//
+// Returns the timestamp of a snowflake ID, as the number of time units since the layout epoch.
+//
+// # Arguments
+//
+//   - s [Snowflake]: Snowflake ID.
+//
+// # Return
+//
+//   - uint64: Timestamp of the snowflake ID.
+//
+// (No errors and examples)
+func (l *Layout) Timestamp(s Snowflake) uint64 {
+	return uint64(s) >> l.timestampShift & l.timestampMask
+}
+
+// # Method UnixMilli(s) of Layout
+//
+// Returns creation date and time of a snowflake ID as milliseconds in unix format, rounded down
+// if the time unit is shorter than a millisecond.
+//
+// # Arguments
+//
+//   - s [Snowflake]: Snowflake ID.
+//
+// # Return
+//
+//   - uint64: Unix timestamp in milliseconds.
+//
+// (No errors and examples)
+func (l *Layout) UnixMilli(s Snowflake) uint64 {
+	return l.base.unixMilli(l.Timestamp(s))
+}
+
+// # Method Time(s) of Layout
+//
+// Returns creation date and time of a snowflake ID. Exact for any time unit.
+//
+// # Arguments
+//
+//   - s [Snowflake]: Snowflake ID.
+//
+// # Return
+//
+//   - [time.Time]: Creation date and time.
+//
+// (No errors and examples)
+func (l *Layout) Time(s Snowflake) time.Time {
+	return l.base.time(l.Timestamp(s))
+}
+
+// # Method TimestampOf(t) of Layout
+//
+// Returns the timestamp of snowflake IDs created at t, as the number of time units since the
+// layout epoch, rounded down. Reverse of [Layout.Time]; use the result with [Layout.Encode].
+//
+// # Arguments
+//
+//   - t [time.Time]: Date and time.
+//
+// # Return
+//
+//   - uint64: Timestamp.
+//   - error
+//
+// # Errors
+//
+//   - [TimeBeforeEpochError]: If t is before the layout epoch.
+//   - [TimeOverflowError]: If the timestamp does not fit into the layout.
+//
+// (No examples)
+func (l *Layout) TimestampOf(t time.Time) (uint64, error) {
+	return l.base.ticks(t, l.timestampMask)
+}
+
+// # Method Field(s, name) of Layout
+//
+// Returns the value of a named field of a snowflake ID.
+//
+// # Arguments
+//
+//   - s [Snowflake]: Snowflake ID.
+//   - name string: Name of the field.
+//
+// # Return
+//
+//   - uint64: Value of the field.
+//   - bool: False if the layout has no such field.
+//
+// # Examples
+//
+//	s := snowflake.Snowflake(175928847299117209)
+//	wid, _ := snowflake.LayoutDiscord.Field(s, snowflake.FieldWorkerID)
+//	fmt.Println(wid) // 1
+//
+// (No errors)
+func (l *Layout) Field(s Snowflake, name string) (uint64, bool) {
+	i := l.index(name)
+	if i < 0 {
+		return 0, false
+	}
+	return l.field(s, i), true
+}
+
+// # Method Decode(s) of Layout
+//
+// Splits a snowflake ID into the timestamp and the values of all layout fields.
+//
+// # Arguments
+//
+//   - s [Snowflake]: Snowflake ID.
+//
+// # Return
+//
+//   - [Decoded]: Timestamp and field values.
+//
+// # Examples
+//
+//	d := snowflake.LayoutDiscord.Decode(175928847299117209)
+//	fmt.Println(d.Timestamp, d.Fields) // 41944705796 [1 0 153]
+//
+// (No errors)
+func (l *Layout) Decode(s Snowflake) Decoded {
+	d := Decoded{Timestamp: l.Timestamp(s), Fields: make([]uint64, len(l.fields))}
+	for i := range l.fields {
+		d.Fields[i] = l.field(s, i)
+	}
+	return d
+}
+
+// # Method Encode(d) of Layout
+//
+// Builds a snowflake ID from the timestamp and the values of all layout fields. Reverse of
+// [Layout.Decode].
+//
+// # Arguments
+//
+//   - d [Decoded]: Timestamp and field values, in the layout order.
+//
+// # Return
+//
+//   - [Snowflake]: Snowflake ID.
+//   - error
+//
+// # Errors
+//
+//   - [LayoutError]: If the number of field values does not match the layout.
+//   - [FieldOverflowError]: If the timestamp or a field value does not fit into its bits.
+//
+// # Examples
+//
+//	s, _ := snowflake.LayoutDiscord.Encode(snowflake.Decoded{
+//		Timestamp: 41944705796,
+//		Fields:    []uint64{1, 0, 153},
+//	})
+//	fmt.Println(s) // 175928847299117209
+func (l *Layout) Encode(d Decoded) (Snowflake, error) {
+	if len(d.Fields) != len(l.fields) {
+		return 0, newLayoutError("layout %q has %d fields, got %d values",
+			l.name, len(l.fields), len(d.Fields))
+	}
+	if d.Timestamp > l.timestampMask {
+		return 0, newFieldOverflowError("timestamp", d.Timestamp, l.timestampMask)
+	}
+
+	v := d.Timestamp << l.timestampShift
+	for i, f := range l.fields {
+		if d.Fields[i] > f.mask {
+			return 0, newFieldOverflowError(f.Name, d.Fields[i], f.mask)
+		}
+		v |= d.Fields[i] << f.shift
+	}
+	return Snowflake(v), nil
+}
+
+// Returns whether the bits of a snowflake ID above the layout width are all zero.
+func (l *Layout) fits(s Snowflake) bool {
+	return l.bits == 64 || uint64(s)>>l.bits == 0
+}
+
+// Returns the index of a named field, or -1 if the layout has no such field.
+func (l *Layout) index(name string) int {
+	for i := range l.fields {
+		if l.fields[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns the value of the i-th field of a snowflake ID.
+func (l *Layout) field(s Snowflake, i int) uint64 {
+	return uint64(s) >> l.fields[i].shift & l.fields[i].mask
+}
+
+// Returns a mask of the lowest bits.
+func mask(bits uint8) uint64 {
+	if bits >= 64 {
+		return 1<<64 - 1
+	}
+	return 1<<bits - 1
+}
+
+func newLayoutError(format string, args ...any) *LayoutError {
+	return &LayoutError{SnowflakeError{message: fmt.Sprintf(format, args...)}}
+}
@@ -0,0 +1,163 @@
+package snowflake_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gophercord/snowflake"
+)
+
+func TestNewLayout(t *testing.T) {
+	tests := []struct {
+		config snowflake.LayoutConfig
+		ok     bool
+	}{
+		{snowflake.LayoutConfig{TimestampBits: 64}, true},
+		{snowflake.LayoutConfig{TimestampBits: 41, Fields: []snowflake.Field{{"a", 10}, {"b", 12}}},
+			true},
+		{snowflake.LayoutConfig{}, false},
+		{snowflake.LayoutConfig{TimestampBits: 42, Fields: []snowflake.Field{{"a", 23}}}, false},
+		{snowflake.LayoutConfig{TimestampBits: 42, Fields: []snowflake.Field{{"", 10}}}, false},
+		{snowflake.LayoutConfig{TimestampBits: 42, Fields: []snowflake.Field{{"a", 0}}}, false},
+		{snowflake.LayoutConfig{TimestampBits: 42, Fields: []snowflake.Field{{"a", 1}, {"a", 1}}},
+			false},
+	}
+
+	for i, test := range tests {
+		_, err := snowflake.NewLayout(test.config)
+		var layoutErr *snowflake.LayoutError
+		if test.ok != (err == nil) || (err != nil && !errors.As(err, &layoutErr)) {
+			t.Errorf("FAIL TestNewLayout[%d]: unexpected error %v", i, err)
+		}
+	}
+}
+
+func TestLayoutDiscord(t *testing.T) {
+	ids := []snowflake.Snowflake{example, 1363292549053284505, 0, 1<<64 - 1}
+
+	for i, s := range ids {
+		d := snowflake.LayoutDiscord.Decode(s)
+		want := []uint64{uint64(s.WorkerID()), uint64(s.ProcessID()), uint64(s.Sequence())}
+		if !reflect.DeepEqual(d.Fields, want) {
+			t.Errorf("FAIL TestLayoutDiscord[%d]: fields %v, want %v", i, d.Fields, want)
+		}
+		if got := snowflake.LayoutDiscord.Time(s); !got.Equal(s.Time()) {
+			t.Errorf("FAIL TestLayoutDiscord[%d]: time %s, want %s", i, got, s.Time())
+		}
+		if back, err := snowflake.LayoutDiscord.Encode(d); err != nil || back != s {
+			t.Errorf("FAIL TestLayoutDiscord[%d]: encoded %d, %v", i, back, err)
+		}
+	}
+
+	if wid, ok := snowflake.LayoutDiscord.Field(example, snowflake.FieldWorkerID); !ok || wid != 1 {
+		t.Errorf("FAIL TestLayoutDiscord: worker ID %d, %v", wid, ok)
+	}
+	if _, ok := snowflake.LayoutDiscord.Field(example, "shard ID"); ok {
+		t.Errorf("FAIL TestLayoutDiscord: unknown field found")
+	}
+}
+
+func TestLayoutEncode(t *testing.T) {
+	layout := snowflake.MustNewLayout(snowflake.LayoutConfig{
+		TimestampBits: 39,
+		Fields:        []snowflake.Field{{"sequence", 8}, {"machine ID", 16}},
+	})
+
+	tests := []struct {
+		decoded snowflake.Decoded
+		want    snowflake.Snowflake
+		err     error
+	}{
+		{snowflake.Decoded{Timestamp: 1, Fields: []uint64{2, 3}}, 1<<24 | 2<<16 | 3, nil},
+		{snowflake.Decoded{Timestamp: 1<<39 - 1, Fields: []uint64{255, 65535}}, 1<<63 - 1, nil},
+		{snowflake.Decoded{Timestamp: 1 << 39, Fields: []uint64{0, 0}}, 0,
+			&snowflake.FieldOverflowError{}},
+		{snowflake.Decoded{Fields: []uint64{256, 0}}, 0, &snowflake.FieldOverflowError{}},
+		{snowflake.Decoded{Fields: []uint64{0}}, 0, &snowflake.LayoutError{}},
+	}
+
+	for i, test := range tests {
+		got, err := layout.Encode(test.decoded)
+		if !sameErrorType(err, test.err) || got != test.want {
+			t.Errorf("FAIL TestLayoutEncode[%d]: got %d, %v, want %d, %T",
+				i, got, err, test.want, test.err)
+		}
+	}
+}
+
+func TestBuiltinLayouts(t *testing.T) {
+	tests := []struct {
+		layout *snowflake.Layout
+		id     snowflake.Snowflake
+		time   time.Time
+		fields []uint64
+		// Precision of the expected time: public sources only give some IDs to the second.
+		precision time.Duration
+	}{
+		// Example snowflake from the Discord developer documentation.
+		{snowflake.LayoutDiscord, 175928847299117063,
+			time.Date(2016, 4, 30, 11, 18, 25, 796e6, time.UTC), []uint64{1, 0, 7}, 0},
+		// Example Tweet from the Twitter API v2 documentation, created at 2018-10-10T20:19:24Z.
+		{snowflake.LayoutTwitter, 1050118621198921728,
+			time.Date(2018, 10, 10, 20, 19, 24, 0, time.UTC), []uint64{347, 0}, time.Second},
+		{snowflake.LayoutTwitterSplit, 1050118621198921728,
+			time.Date(2018, 10, 10, 20, 19, 24, 0, time.UTC), []uint64{10, 27, 0}, time.Second},
+		// Example status from the Mastodon API documentation, created at 2019-12-08T03:48:33Z.
+		{snowflake.LayoutMastodon, 103270115826048975,
+			time.Date(2019, 12, 8, 3, 48, 33, 0, time.UTC), []uint64{40911}, time.Second},
+		// Media ID of the public Instagram post instagram.com/p/BsOGulcndj- (the "world record
+		// egg"), taken at 2019-01-04T17:05:45Z.
+		{snowflake.LayoutInstagram, 1949525278281554174,
+			time.Date(2019, 1, 4, 17, 5, 45, 0, time.UTC), []uint64{1910, 254}, time.Second},
+	}
+
+	for i, test := range tests {
+		got := test.layout.Time(test.id)
+		if test.precision > 0 {
+			got = got.Truncate(test.precision)
+		}
+		if !got.Equal(test.time) {
+			t.Errorf("FAIL TestBuiltinLayouts[%d]: %s time %s, want %s",
+				i, test.layout.Name(), got, test.time)
+		}
+
+		d := test.layout.Decode(test.id)
+		if !reflect.DeepEqual(d.Fields, test.fields) {
+			t.Errorf("FAIL TestBuiltinLayouts[%d]: %s fields %v, want %v",
+				i, test.layout.Name(), d.Fields, test.fields)
+		}
+		if back, err := test.layout.Encode(d); err != nil || back != test.id {
+			t.Errorf("FAIL TestBuiltinLayouts[%d]: %s encoded %d, %v",
+				i, test.layout.Name(), back, err)
+		}
+	}
+}
+
+// There is no well-known public Sonyflake ID, so the layout is checked against an ID assembled
+// by hand from the Sonyflake specification instead: 2020-01-01 00:00:00 UTC, sequence 3 and
+// machine ID 0xBEEF.
+func TestLayoutSonyflake(t *testing.T) {
+	var id snowflake.Snowflake = (1577836800000-1409529600000)/10<<24 | 3<<16 | 0xBEEF
+
+	want := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := snowflake.LayoutSonyflake.Time(id); !got.Equal(want) {
+		t.Errorf("FAIL TestLayoutSonyflake: time %s, want %s", got, want)
+	}
+	d := snowflake.LayoutSonyflake.Decode(id)
+	if !reflect.DeepEqual(d.Fields, []uint64{3, 0xBEEF}) {
+		t.Errorf("FAIL TestLayoutSonyflake: fields %v, want [3 48879]", d.Fields)
+	}
+}
+
+func TestLayoutTimeUnit(t *testing.T) {
+	_, err := snowflake.NewLayout(snowflake.LayoutConfig{TimestampBits: 41, TimeUnit: -1})
+	if !sameErrorType(err, &snowflake.LayoutError{}) {
+		t.Errorf("FAIL TestLayoutTimeUnit: negative time unit, got %v", err)
+	}
+
+	if unit := snowflake.LayoutSonyflake.TimeUnit(); unit != 10*time.Millisecond {
+		t.Errorf("FAIL TestLayoutTimeUnit: sonyflake time unit %s", unit)
+	}
+}
@@ -0,0 +1,381 @@
+package snowflake
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Default value of [LeaseConfig.TTL].
+const DefaultLeaseTTL = 30 * time.Second
+
+// Configuration of a worker ID and process ID lease. Passed to [AcquireLease].
+type LeaseConfig struct {
+	// Directory with lease files, shared by all processes that must not use the same worker ID
+	// and process ID pair. Created if it does not exist.
+	Dir string
+
+	// How long a lease stays valid without a heartbeat. A lease file whose heartbeat is older
+	// than TTL is considered left behind by a dead process and is reclaimed. If zero,
+	// [DefaultLeaseTTL] is used.
+	TTL time.Duration
+
+	// How often the lease file is touched to show that its owner is alive. Must be well below
+	// TTL. If zero, a third of TTL is used.
+	HeartbeatInterval time.Duration
+}
+
+// Exclusive claim of a worker ID and process ID pair on a host (or on any set of processes
+// sharing the lease directory). Safe for concurrent use by multiple goroutines.
+//
+// A lease is a file named after the pair, created exclusively in the lease directory. The file
+// holds the owner PID, hostname and a random token, and its modification time is the owner
+// heartbeat, updated in the background until [Lease.Release] is called. If the owner dies
+// without releasing the lease, the file is reclaimed when its heartbeat becomes older than the
+// TTL. On Unix, the owner PID decides instead of the heartbeat for leases of the same host: the
+// lease is reclaimed as soon as the PID no longer exists, and never while it exists, even if
+// the heartbeat is late because the owner was suspended.
+//
+// Leases of other hosts can still be reclaimed while their owner is alive, if its heartbeat is
+// late. The heartbeat notices that and closes the [Lease.Lost] channel; the owner must stop
+// generating IDs with the pair then.
+//
+// Create leases with [AcquireLease].
+type Lease struct {
+	workerID  uint8
+	processID uint8
+	dir       string
+	path      string
+	content   []byte
+
+	once sync.Once
+	stop chan struct{}
+	done chan struct{}
+
+	mu   sync.Mutex
+	err  error
+	lost chan struct{}
+}
+
+// # Function AcquireLease(config)
+//
+// Claims the first free worker ID and process ID pair in the lease directory. Pairs are tried
+// in order: worker 0 process 0, worker 0 process 1, ..., worker 31 process 31.
+//
+// # Arguments
+//
+//   - config [LeaseConfig]: Lease configuration.
+//
+// # Return
+//
+//   - *[Lease]: New lease. Call [Lease.Release] on shutdown.
+//   - error
+//
+// # Errors
+//
+//   - [LeaseError]: If all pairs are leased, or the lease directory or file can not be used.
+//
+// # Examples
+//
+//	lease, err := snowflake.AcquireLease(snowflake.LeaseConfig{Dir: "/run/mybot"})
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer lease.Release()
+//
+//	g := snowflake.MustNewGenerator(lease.GeneratorConfig())
+func AcquireLease(config LeaseConfig) (*Lease, error) {
+	if config.TTL <= 0 {
+		config.TTL = DefaultLeaseTTL
+	}
+	if config.HeartbeatInterval <= 0 {
+		config.HeartbeatInterval = config.TTL / 3
+	}
+	if err := os.MkdirAll(config.Dir, 0o755); err != nil {
+		return nil, newLeaseError("unable to create lease directory", err)
+	}
+	unlock, err := lockLeases(config.Dir)
+	if err != nil {
+		return nil, newLeaseError("unable to lock lease directory", err)
+	}
+	defer unlock()
+
+	hostname, _ := os.Hostname()
+	token := make([]byte, 8)
+	if _, err := rand.Read(token); err != nil {
+		return nil, newLeaseError("unable to generate lease token", err)
+	}
+	content := []byte(fmt.Sprintf("pid=%d\nhost=%s\ntoken=%s\n",
+		os.Getpid(), hostname, hex.EncodeToString(token)))
+
+	for worker := 0; worker <= MaxWorkerID; worker++ {
+		for process := 0; process <= MaxProcessID; process++ {
+			name := fmt.Sprintf("snowflake-%02d-%02d.lease", worker, process)
+			path := filepath.Join(config.Dir, name)
+
+			ok, err := claimLease(path, content, config.TTL, hostname)
+			if err != nil {
+				return nil, newLeaseError("unable to claim lease "+path, err)
+			}
+			if !ok {
+				continue
+			}
+
+			l := &Lease{
+				workerID:  uint8(worker),
+				processID: uint8(process),
+				dir:       config.Dir,
+				path:      path,
+				content:   content,
+				stop:      make(chan struct{}),
+				done:      make(chan struct{}),
+				lost:      make(chan struct{}),
+			}
+			go l.heartbeat(config.HeartbeatInterval)
+			return l, nil
+		}
+	}
+
+	return nil, newLeaseError("all worker ID and process ID pairs are leased in "+config.Dir, nil)
+}
+
+// # Method WorkerID() of Lease
+//
+// Returns leased worker ID.
+//
+// # Return
+//
+//   - uint8: Worker ID.
+//
+// (No arguments, errors, and examples)
+func (l *Lease) WorkerID() uint8 {
+	return l.workerID
+}
+
+// # Method ProcessID() of Lease
+//
+// Returns leased process ID.
+//
+// # Return
+//
+//   - uint8: Process ID.
+//
+// (No arguments, errors, and examples)
+func (l *Lease) ProcessID() uint8 {
+	return l.processID
+}
+
+// # Method GeneratorConfig() of Lease
+//
+// Returns generator configuration with leased worker ID and process ID.
+//
+// # Return
+//
+//   - [GeneratorConfig]: Generator configuration.
+//
+// (No arguments, errors, and examples)
+func (l *Lease) GeneratorConfig() GeneratorConfig {
+	return GeneratorConfig{WorkerID: l.workerID, ProcessID: l.processID}
+}
+
+// # Method Lost() of Lease
+//
+// Returns a channel that is closed when the lease is lost: its file was removed, or reclaimed by
+// another process because the heartbeat was late. Another process may use the pair from then on,
+// so IDs must no longer be generated with it. The channel is not closed by [Lease.Release].
+//
+// # Return
+//
+//   - <-chan struct{}: Channel closed when the lease is lost.
+//
+// # Examples
+//
+//	go func() {
+//		<-lease.Lost()
+//		log.Fatal(lease.Err())
+//	}()
+//
+// (No arguments and errors)
+func (l *Lease) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// # Method Err() of Lease
+//
+// Returns why the lease was lost, or nil if it is not lost. See [Lease.Lost].
+//
+// # Return
+//
+//   - error
+//
+// # Errors
+//
+//   - [LeaseError]: If the lease is lost.
+//
+// (No arguments and examples)
+func (l *Lease) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// # Method Release() of Lease
+//
+// Stops the heartbeat and removes the lease file, so the pair can be claimed by other
+// processes. Does nothing if the lease is already released, or if the lease file was reclaimed
+// by another process.
+//
+// # Return
+//
+//   - error
+//
+// # Errors
+//
+//   - [LeaseError]: If the lease file can not be removed.
+//
+// (No arguments and examples)
+func (l *Lease) Release() error {
+	var err error
+	l.once.Do(func() {
+		close(l.stop)
+		<-l.done
+
+		unlock, lockErr := lockLeases(l.dir)
+		if lockErr != nil {
+			err = newLeaseError("unable to lock lease directory", lockErr)
+			return
+		}
+		defer unlock()
+
+		content, readErr := os.ReadFile(l.path)
+		if readErr != nil || !bytes.Equal(content, l.content) {
+			return
+		}
+		if removeErr := os.Remove(l.path); removeErr != nil && !os.IsNotExist(removeErr) {
+			err = newLeaseError("unable to remove lease "+l.path, removeErr)
+		}
+	})
+	return err
+}
+
+func (l *Lease) heartbeat(interval time.Duration) {
+	defer close(l.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+			// Other errors are ignored: the next heartbeat tries again.
+			if lost := l.touch(); lost != nil {
+				l.mu.Lock()
+				l.err = lost
+				l.mu.Unlock()
+				close(l.lost)
+				return
+			}
+		}
+	}
+}
+
+// Updates modification time of the lease file, if the file still holds this lease. Returns
+// LeaseError if it does not. The file is checked and written through the same descriptor, so a
+// lease file created by another process in the meantime is never touched.
+func (l *Lease) touch() *LeaseError {
+	file, err := os.OpenFile(l.path, os.O_RDWR, 0)
+	if os.IsNotExist(err) {
+		return newLeaseError("lease file "+l.path+" was removed", nil)
+	}
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil
+	}
+	if !bytes.Equal(content, l.content) {
+		return newLeaseError("lease "+l.path+" was reclaimed by another process", nil)
+	}
+	// Writing the same content updates the modification time.
+	file.WriteAt(l.content, 0)
+	return nil
+}
+
+// Tries to create the lease file at path, reclaiming it if it is stale. Returns false if the
+// lease is owned by a live process. The lease directory must be locked.
+func claimLease(path string, content []byte, ttl time.Duration, hostname string) (bool, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			_, err = file.Write(content)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(path)
+				return false, err
+			}
+			return true, nil
+		}
+		if !os.IsExist(err) {
+			return false, err
+		}
+
+		if !staleLease(path, ttl, hostname) {
+			return false, nil
+		}
+		// The directory is locked, so no other process can claim the lease in the meantime.
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// Reports whether the lease file at path is left behind by a dead process.
+func staleLease(path string, ttl time.Duration, hostname string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	var pid int
+	var host string
+	for _, line := range bytes.Split(content, []byte("\n")) {
+		key, value, _ := bytes.Cut(line, []byte("="))
+		switch string(key) {
+		case "pid":
+			pid, _ = strconv.Atoi(string(value))
+		case "host":
+			host = string(value)
+		}
+	}
+	// On the same host, the owner PID tells whether the owner is alive even if its heartbeat
+	// is late.
+	if pid > 0 && host == hostname {
+		if alive, ok := processAlive(pid); ok {
+			return !alive
+		}
+	}
+	return time.Since(info.ModTime()) > ttl
+}
+
+func newLeaseError(message string, err error) *LeaseError {
+	return &LeaseError{SnowflakeError: SnowflakeError{message: message, err: err}}
+}
@@ -0,0 +1,42 @@
+//go:build !unix
+
+package snowflake
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Age after which a lock file of the lease directory is considered left behind by a dead
+// process.
+const leaseLockTTL = 10 * time.Second
+
+// Reports whether a process with the given PID exists on this host. The second result is false:
+// the check is not supported on this platform, so stale leases are detected only by their
+// heartbeat.
+func processAlive(pid int) (bool, bool) {
+	return false, false
+}
+
+// Locks the lease directory, so that no other process claims, reclaims or releases a lease until
+// unlock is called. Without file locks on this platform, the lock is a file created exclusively
+// in the directory. It is removed if it gets older than leaseLockTTL, in case its owner died.
+func lockLeases(dir string) (unlock func(), err error) {
+	path := filepath.Join(dir, "snowflake.lock")
+	for {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			file.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > leaseLockTTL {
+			os.Remove(path)
+			continue
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
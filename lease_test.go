@@ -0,0 +1,240 @@
+package snowflake_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gophercord/snowflake"
+)
+
+func TestAcquireLease(t *testing.T) {
+	dir := t.TempDir()
+	config := snowflake.LeaseConfig{Dir: dir}
+
+	a, err := snowflake.AcquireLease(config)
+	if err != nil {
+		t.Fatalf("FAIL TestAcquireLease: AcquireLease returned error %v", err)
+	}
+	b, err := snowflake.AcquireLease(config)
+	if err != nil {
+		t.Fatalf("FAIL TestAcquireLease: second AcquireLease returned error %v", err)
+	}
+
+	if a.WorkerID() != 0 || a.ProcessID() != 0 || b.WorkerID() != 0 || b.ProcessID() != 1 {
+		t.Errorf("FAIL TestAcquireLease: got pairs (%d, %d) and (%d, %d), wanted (0, 0) and "+
+			"(0, 1)",
+			a.WorkerID(), a.ProcessID(), b.WorkerID(), b.ProcessID())
+	}
+
+	if err := a.Release(); err != nil {
+		t.Errorf("FAIL TestAcquireLease: Release returned error %v", err)
+	}
+	if err := a.Release(); err != nil {
+		t.Errorf("FAIL TestAcquireLease: second Release returned error %v", err)
+	}
+
+	c, err := snowflake.AcquireLease(config)
+	if err != nil || c.WorkerID() != 0 || c.ProcessID() != 0 {
+		t.Errorf("FAIL TestAcquireLease: released pair was not claimed again (%v)", err)
+	}
+
+	b.Release()
+	c.Release()
+}
+
+func TestAcquireLeaseExhausted(t *testing.T) {
+	dir := t.TempDir()
+	hostname, _ := os.Hostname()
+
+	// Leases of a live process (this one) for all pairs except the last.
+	content := []byte(fmt.Sprintf("pid=%d\nhost=%s\n", os.Getpid(), hostname))
+	for worker := 0; worker <= snowflake.MaxWorkerID; worker++ {
+		for process := 0; process <= snowflake.MaxProcessID; process++ {
+			if worker == snowflake.MaxWorkerID && process == snowflake.MaxProcessID {
+				continue
+			}
+			name := fmt.Sprintf("snowflake-%02d-%02d.lease", worker, process)
+			os.WriteFile(filepath.Join(dir, name), content, 0o644)
+		}
+	}
+
+	l, err := snowflake.AcquireLease(snowflake.LeaseConfig{Dir: dir})
+	if err != nil {
+		t.Fatalf("FAIL TestAcquireLeaseExhausted: AcquireLease returned error %v", err)
+	}
+	defer l.Release()
+	if l.WorkerID() != snowflake.MaxWorkerID || l.ProcessID() != snowflake.MaxProcessID {
+		t.Errorf("FAIL TestAcquireLeaseExhausted: got pair (%d, %d), wanted the last one",
+			l.WorkerID(), l.ProcessID())
+	}
+
+	_, err = snowflake.AcquireLease(snowflake.LeaseConfig{Dir: dir})
+	var leaseErr *snowflake.LeaseError
+	if !errors.As(err, &leaseErr) {
+		t.Errorf("FAIL TestAcquireLeaseExhausted: wanted LeaseError, got %v", err)
+	}
+}
+
+func TestAcquireLeaseConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	leases := make([]*snowflake.Lease, 32)
+
+	var wg sync.WaitGroup
+	for i := range leases {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			leases[i], _ = snowflake.AcquireLease(snowflake.LeaseConfig{Dir: dir})
+		}(i)
+	}
+	wg.Wait()
+
+	claimed := make(map[[2]uint8]bool)
+	for i, l := range leases {
+		if l == nil {
+			t.Fatalf("FAIL TestAcquireLeaseConcurrent[%d]: AcquireLease failed", i)
+		}
+		pair := [2]uint8{l.WorkerID(), l.ProcessID()}
+		if claimed[pair] {
+			t.Errorf("FAIL TestAcquireLeaseConcurrent[%d]: pair %v claimed twice", i, pair)
+		}
+		claimed[pair] = true
+		defer l.Release()
+	}
+}
+
+func TestAcquireLeaseStale(t *testing.T) {
+	hostname, _ := os.Hostname()
+
+	// PID of a process that has already exited.
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("FAIL TestAcquireLeaseStale: unable to run process: %v", err)
+	}
+	deadPID := cmd.Process.Pid
+
+	tests := []struct {
+		Content string
+		Age     time.Duration
+		Stale   bool
+	}{
+		{fmt.Sprintf("pid=%d\nhost=%s\n", os.Getpid(), hostname), 0, false},
+		// A live owner on the same host keeps its lease even if its heartbeat is late.
+		{fmt.Sprintf("pid=%d\nhost=%s\n", os.Getpid(), hostname), time.Hour,
+			runtime.GOOS == "windows"},
+		{fmt.Sprintf("pid=%d\nhost=other-host\n", deadPID), 0, false},
+		{fmt.Sprintf("pid=%d\nhost=other-host\n", os.Getpid()), time.Hour, true},
+		{fmt.Sprintf("pid=%d\nhost=%s\n", deadPID, hostname), 0, runtime.GOOS != "windows"},
+	}
+
+	for i, test := range tests {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "snowflake-00-00.lease")
+		os.WriteFile(path, []byte(test.Content), 0o644)
+		modified := time.Now().Add(-test.Age)
+		os.Chtimes(path, modified, modified)
+
+		l, err := snowflake.AcquireLease(snowflake.LeaseConfig{Dir: dir, TTL: time.Minute})
+		if err != nil {
+			t.Fatalf("FAIL TestAcquireLeaseStale[%d]: AcquireLease returned error %v", i, err)
+		}
+
+		reclaimed := l.WorkerID() == 0 && l.ProcessID() == 0
+		if reclaimed != test.Stale {
+			t.Errorf("FAIL TestAcquireLeaseStale[%d]: lease reclaimed=%v, wanted %v",
+				i, reclaimed, test.Stale)
+		}
+		l.Release()
+	}
+}
+
+func TestLeaseHeartbeat(t *testing.T) {
+	dir := t.TempDir()
+	l, err := snowflake.AcquireLease(snowflake.LeaseConfig{
+		Dir:               dir,
+		TTL:               time.Hour,
+		HeartbeatInterval: 5 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("FAIL TestLeaseHeartbeat: AcquireLease returned error %v", err)
+	}
+	defer l.Release()
+
+	path := filepath.Join(dir, "snowflake-00-00.lease")
+	old := time.Now().Add(-time.Minute)
+	os.Chtimes(path, old, old)
+	time.Sleep(50 * time.Millisecond)
+
+	info, err := os.Stat(path)
+	if err != nil || !info.ModTime().After(old.Add(time.Second)) {
+		t.Errorf("FAIL TestLeaseHeartbeat: lease file was not touched by heartbeat (%v)", err)
+	}
+}
+
+func TestLeaseLost(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Replace bool
+	}{
+		{"reclaimed", true},
+		{"removed", false},
+	}
+
+	for _, test := range tests {
+		dir := t.TempDir()
+		l, err := snowflake.AcquireLease(snowflake.LeaseConfig{
+			Dir:               dir,
+			TTL:               time.Hour,
+			HeartbeatInterval: 5 * time.Millisecond,
+		})
+		if err != nil {
+			t.Fatalf("FAIL TestLeaseLost[%s]: AcquireLease returned error %v", test.Name, err)
+		}
+		if l.Err() != nil {
+			t.Errorf("FAIL TestLeaseLost[%s]: Err returned %v before the lease was lost",
+				test.Name, l.Err())
+		}
+
+		// Another process takes over the lease file.
+		path := filepath.Join(dir, "snowflake-00-00.lease")
+		old := time.Now().Add(-time.Minute)
+		os.Remove(path)
+		if test.Replace {
+			os.WriteFile(path, []byte("pid=1\nhost=other-host\ntoken=other\n"), 0o644)
+			os.Chtimes(path, old, old)
+		}
+
+		select {
+		case <-l.Lost():
+		case <-time.After(time.Second):
+			t.Fatalf("FAIL TestLeaseLost[%s]: Lost channel was not closed", test.Name)
+		}
+		var leaseErr *snowflake.LeaseError
+		if !errors.As(l.Err(), &leaseErr) {
+			t.Errorf("FAIL TestLeaseLost[%s]: wanted LeaseError, got %v", test.Name, l.Err())
+		}
+
+		if test.Replace {
+			info, err := os.Stat(path)
+			if err != nil || info.ModTime().After(old.Add(time.Second)) {
+				t.Errorf("FAIL TestLeaseLost[%s]: heartbeat touched the new lease file (%v)",
+					test.Name, err)
+			}
+		}
+		if err := l.Release(); err != nil {
+			t.Errorf("FAIL TestLeaseLost[%s]: Release returned error %v", test.Name, err)
+		}
+		if test.Replace {
+			if _, err := os.Stat(path); err != nil {
+				t.Errorf("FAIL TestLeaseLost[%s]: Release removed the new lease file", test.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,31 @@
+//go:build unix
+
+package snowflake
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// Reports whether a process with the given PID exists on this host. The second result is always
+// true: the check is supported on this platform.
+func processAlive(pid int) (bool, bool) {
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM, true
+}
+
+// Locks the lease directory, so that no other process claims, reclaims or releases a lease until
+// unlock is called. The lock is released by the system if the process dies.
+func lockLeases(dir string) (unlock func(), err error) {
+	file, err := os.OpenFile(filepath.Join(dir, "snowflake.lock"), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX); err != nil {
+		file.Close()
+		return nil, err
+	}
+	// Closing the file releases the lock.
+	return func() { file.Close() }, nil
+}
@@ -0,0 +1,176 @@
+package snowflake
+
+import "time"
+
+// Fields of a snowflake ID with the Discord layout. Returned by [Snowflake.Deconstruct] and
+// accepted by [Compose].
+type Parts struct {
+	Timestamp uint64 // Number of milliseconds since epoch (0 to [MaxTimestamp]).
+	WorkerID  uint8  // Internal worker ID (0 to [MaxWorkerID]).
+	ProcessID uint8  // Internal process ID (0 to [MaxProcessID]).
+	Sequence  uint16 // Sequence number (0 to [MaxSequence]).
+}
+
+// # Method Deconstruct() of Snowflake
+//
+// Returns all fields of snowflake ID at once. The timestamp is not converted to a date and time,
+// so the result does not depend on [Epoch].
+//
+// # Return
+//
+//   - [Parts]: Fields of the snowflake ID.
+//
+// # Examples
+//
+//	p := snowflake.Snowflake(175928847299117063).Deconstruct()
+//	fmt.Println(p.Timestamp, p.WorkerID, p.ProcessID, p.Sequence) // 41944705796 1 0 7
+//
+// (No arguments and errors)
+func (s Snowflake) Deconstruct() Parts {
+	return Parts{
+		Timestamp: discord.Timestamp(s),
+		WorkerID:  s.WorkerID(),
+		ProcessID: s.ProcessID(),
+		Sequence:  s.Sequence(),
+	}
+}
+
+// # Function Compose(p)
+//
+// Builds a snowflake ID from its fields. Reverse of [Snowflake.Deconstruct].
+//
+// # Arguments
+//
+//   - p [Parts]: Fields of the snowflake ID.
+//
+// # Return
+//
+//   - [Snowflake]: New snowflake ID.
+//   - error
+//
+// # Errors
+//
+//   - [FieldOverflowError]: If a field is out of range, for example a worker ID above
+//     [MaxWorkerID].
+//
+// # Examples
+//
+//	s, err := snowflake.Compose(snowflake.Parts{Timestamp: 41944705796, WorkerID: 1, Sequence: 7})
+//	fmt.Println(s, err) // 175928847299117063 <nil>
+//
+//	_, err = snowflake.Compose(snowflake.Parts{WorkerID: 32})
+//	fmt.Println(err) // worker ID 32 is out of range 0-31
+func Compose(p Parts) (Snowflake, error) {
+	return discord.Encode(Decoded{
+		Timestamp: p.Timestamp,
+		Fields:    []uint64{uint64(p.WorkerID), uint64(p.ProcessID), uint64(p.Sequence)},
+	})
+}
+
+// # Method WithTime(t) of Snowflake
+//
+// Returns a copy of snowflake ID with the creation time replaced by t, rounded down to whole
+// milliseconds. Other fields are left as they are. Reads the package-level [Epoch].
+//
+// # Arguments
+//
+//   - t [time.Time]: New creation date and time.
+//
+// # Return
+//
+//   - [Snowflake]: New snowflake ID.
+//   - error
+//
+// # Errors
+//
+//   - [TimeBeforeEpochError]: If t is before [Epoch].
+//   - [TimeOverflowError]: If t is after the largest time that fits into 42 bits.
+//
+// # Examples
+//
+//	s := snowflake.Snowflake(175928847299117063)
+//	later, _ := s.WithTime(s.Time().Add(time.Hour))
+//	fmt.Println(later.WorkerID(), later.Sequence()) // 1 7
+func (s Snowflake) WithTime(t time.Time) (Snowflake, error) {
+	ts, err := timeBase{epoch: Epoch, unit: time.Millisecond}.ticks(t, MaxTimestamp)
+	if err != nil {
+		return 0, err
+	}
+	p := s.Deconstruct()
+	p.Timestamp = ts
+	return Compose(p)
+}
+
+// # Method WithWorkerID(id) of Snowflake
+//
+// Returns a copy of snowflake ID with the internal worker ID replaced. Other fields are left as
+// they are.
+//
+// # Arguments
+//
+//   - id uint8: New internal worker ID.
+//
+// # Return
+//
+//   - [Snowflake]: New snowflake ID.
+//   - error
+//
+// # Errors
+//
+//   - [FieldOverflowError]: If id is greater than [MaxWorkerID].
+//
+// (No examples)
+func (s Snowflake) WithWorkerID(id uint8) (Snowflake, error) {
+	p := s.Deconstruct()
+	p.WorkerID = id
+	return Compose(p)
+}
+
+// # Method WithProcessID(id) of Snowflake
+//
+// Returns a copy of snowflake ID with the internal process ID replaced. Other fields are left as
+// they are.
+//
+// # Arguments
+//
+//   - id uint8: New internal process ID.
+//
+// # Return
+//
+//   - [Snowflake]: New snowflake ID.
+//   - error
+//
+// # Errors
+//
+//   - [FieldOverflowError]: If id is greater than [MaxProcessID].
+//
+// (No examples)
+func (s Snowflake) WithProcessID(id uint8) (Snowflake, error) {
+	p := s.Deconstruct()
+	p.ProcessID = id
+	return Compose(p)
+}
+
+// # Method WithSequence(seq) of Snowflake
+//
+// Returns a copy of snowflake ID with the sequence replaced. Other fields are left as they are.
+//
+// # Arguments
+//
+//   - seq uint16: New sequence number.
+//
+// # Return
+//
+//   - [Snowflake]: New snowflake ID.
+//   - error
+//
+// # Errors
+//
+//   - [FieldOverflowError]: If seq is greater than [MaxSequence].
+//
+// (No examples)
+func (s Snowflake) WithSequence(seq uint16) (Snowflake, error) {
+	p := s.Deconstruct()
+	p.Sequence = seq
+	return Compose(p)
+}
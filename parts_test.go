@@ -0,0 +1,123 @@
+package snowflake_test
+
+import (
+	"errors"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/gophercord/snowflake"
+)
+
+func TestCompose(t *testing.T) {
+	tests := []struct {
+		parts snowflake.Parts
+		want  snowflake.Snowflake
+		field string // Name of the field that overflows.
+	}{
+		{snowflake.Parts{Timestamp: 41944705796, WorkerID: 1, Sequence: 7}, 175928847299117063, ""},
+		{snowflake.Parts{}, 0, ""},
+		{snowflake.Parts{snowflake.MaxTimestamp, snowflake.MaxWorkerID, snowflake.MaxProcessID,
+			snowflake.MaxSequence}, 1<<64 - 1, ""},
+		{snowflake.Parts{Timestamp: snowflake.MaxTimestamp + 1}, 0, "timestamp"},
+		{snowflake.Parts{WorkerID: snowflake.MaxWorkerID + 1}, 0, snowflake.FieldWorkerID},
+		{snowflake.Parts{ProcessID: 255}, 0, snowflake.FieldProcessID},
+		{snowflake.Parts{Sequence: snowflake.MaxSequence + 1}, 0, snowflake.FieldSequence},
+	}
+
+	for i, test := range tests {
+		s, err := snowflake.Compose(test.parts)
+		var overflow *snowflake.FieldOverflowError
+		switch {
+		case test.field == "" && (err != nil || s != test.want):
+			t.Errorf("FAIL TestCompose[%d]: got %d, %v, want %d", i, s, err, test.want)
+		case test.field != "" && (!errors.As(err, &overflow) || overflow.Field != test.field):
+			t.Errorf("FAIL TestCompose[%d]: got %v, want overflow of %s", i, err, test.field)
+		}
+	}
+}
+
+// Every uint64 is a valid snowflake ID, so deconstructing and composing must give it back.
+func TestDeconstructRoundTrip(t *testing.T) {
+	f := func(v uint64) bool {
+		s := snowflake.Snowflake(v)
+		back, err := snowflake.Compose(s.Deconstruct())
+		return err == nil && back == s
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 10000}); err != nil {
+		t.Errorf("FAIL TestDeconstructRoundTrip: %v", err)
+	}
+}
+
+// Arbitrary parts either compose into an ID with the same parts, or are rejected because a
+// field is out of range.
+func TestComposeRoundTrip(t *testing.T) {
+	f := func(p snowflake.Parts) bool {
+		s, err := snowflake.Compose(p)
+		valid := p.Timestamp <= snowflake.MaxTimestamp && p.WorkerID <= snowflake.MaxWorkerID &&
+			p.ProcessID <= snowflake.MaxProcessID && p.Sequence <= snowflake.MaxSequence
+		if !valid {
+			var overflow *snowflake.FieldOverflowError
+			return errors.As(err, &overflow)
+		}
+		return err == nil && s.Deconstruct() == p
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 10000}); err != nil {
+		t.Errorf("FAIL TestComposeRoundTrip: %v", err)
+	}
+
+	// Random parts are almost never valid, so check masked ones too.
+	g := func(p snowflake.Parts) bool {
+		p.Timestamp &= snowflake.MaxTimestamp
+		p.WorkerID &= snowflake.MaxWorkerID
+		p.ProcessID &= snowflake.MaxProcessID
+		p.Sequence &= snowflake.MaxSequence
+		s, err := snowflake.Compose(p)
+		return err == nil && s.Deconstruct() == p
+	}
+	if err := quick.Check(g, &quick.Config{MaxCount: 10000}); err != nil {
+		t.Errorf("FAIL TestComposeRoundTrip: %v", err)
+	}
+}
+
+func TestWith(t *testing.T) {
+	s := snowflake.Snowflake(175928847299117063)
+	later := s.Time().Add(time.Hour + 1500*time.Microsecond)
+	future := time.Date(2200, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		with func() (snowflake.Snowflake, error)
+		want snowflake.Parts
+		err  error
+	}{
+		{func() (snowflake.Snowflake, error) { return s.WithTime(later) },
+			snowflake.Parts{Timestamp: 41944705796 + 3600001, WorkerID: 1, Sequence: 7}, nil},
+		{func() (snowflake.Snowflake, error) { return s.WithWorkerID(31) },
+			snowflake.Parts{Timestamp: 41944705796, WorkerID: 31, Sequence: 7}, nil},
+		{func() (snowflake.Snowflake, error) { return s.WithProcessID(5) },
+			snowflake.Parts{Timestamp: 41944705796, WorkerID: 1, ProcessID: 5, Sequence: 7}, nil},
+		{func() (snowflake.Snowflake, error) { return s.WithSequence(4095) },
+			snowflake.Parts{Timestamp: 41944705796, WorkerID: 1, Sequence: 4095}, nil},
+		{func() (snowflake.Snowflake, error) { return s.WithWorkerID(32) },
+			snowflake.Parts{}, &snowflake.FieldOverflowError{}},
+		{func() (snowflake.Snowflake, error) { return s.WithProcessID(32) },
+			snowflake.Parts{}, &snowflake.FieldOverflowError{}},
+		{func() (snowflake.Snowflake, error) { return s.WithSequence(4096) },
+			snowflake.Parts{}, &snowflake.FieldOverflowError{}},
+		{func() (snowflake.Snowflake, error) { return s.WithTime(time.UnixMilli(0)) },
+			snowflake.Parts{}, &snowflake.TimeBeforeEpochError{}},
+		{func() (snowflake.Snowflake, error) { return s.WithTime(future) },
+			snowflake.Parts{}, &snowflake.TimeOverflowError{}},
+	}
+
+	for i, test := range tests {
+		got, err := test.with()
+		if !sameErrorType(err, test.err) {
+			t.Errorf("FAIL TestWith[%d]: got error %v, want %T", i, err, test.err)
+			continue
+		}
+		if err == nil && got.Deconstruct() != test.want {
+			t.Errorf("FAIL TestWith[%d]: got %+v, want %+v", i, got.Deconstruct(), test.want)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package snowflake
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+)
+
+// Configuration of a [Pool]. Passed to [NewPool].
+type PoolConfig struct {
+	// Configuration shared by all generators of the pool. Its ProcessID is ignored. If it has a
+	// checkpoint path, every shard uses its own file: the path with "." and the process ID
+	// appended.
+	GeneratorConfig
+
+	// Process IDs owned by the pool, one generator per process ID. Must not be empty and must
+	// not contain duplicates. No other generator with the same worker ID may use these process
+	// IDs.
+	ProcessIDs []uint8
+}
+
+// Pool of generators with the same worker ID and different process IDs. Safe for concurrent use
+// by multiple goroutines.
+//
+// One generator can create at most 4096 snowflake IDs per time unit, because the sequence has
+// 12 bits. A pool spreads callers across its generators (shards) in turn, which multiplies
+// this limit by the number of shards and reduces lock contention. When a shard has exhausted
+// its sequence, the pool tries the other shards before waiting.
+//
+// Snowflake IDs of a pool are unique, but they are ordered only within one shard (IDs with the
+// same process ID are strictly increasing). Two IDs from different shards may come in any
+// order, even if one was generated after the other.
+//
+// Create pools with [NewPool]; the zero value is not usable.
+type Pool struct {
+	shards []*Generator
+	next   atomic.Uint64
+}
+
+// # Function NewPool(config)
+//
+// Creates a new pool of generators.
+//
+// # Arguments
+//
+//   - config [PoolConfig]: Pool configuration.
+//
+// # Return
+//
+//   - *[Pool]: New pool.
+//   - error
+//
+// # Errors
+//
+//   - [SnowflakeError]: If the list of process IDs is empty or has duplicates.
+//   - Same errors as [NewGenerator].
+//
+// # Examples
+//
+//	p, err := snowflake.NewPool(snowflake.PoolConfig{
+//		GeneratorConfig: snowflake.GeneratorConfig{WorkerID: 1},
+//		ProcessIDs:      []uint8{0, 1, 2, 3},
+//	})
+//	if err != nil {
+//		panic(err)
+//	}
+//	s, _ := p.Generate()
+func NewPool(config PoolConfig) (*Pool, error) {
+	if len(config.ProcessIDs) == 0 {
+		return nil, &SnowflakeError{message: "pool has no process IDs"}
+	}
+
+	p := &Pool{shards: make([]*Generator, 0, len(config.ProcessIDs))}
+	used := make(map[uint8]bool, len(config.ProcessIDs))
+	for _, id := range config.ProcessIDs {
+		if used[id] {
+			return nil, &SnowflakeError{
+				message: "pool has duplicate process ID " + strconv.Itoa(int(id)),
+			}
+		}
+		used[id] = true
+
+		shard := config.GeneratorConfig
+		shard.ProcessID = id
+		if shard.CheckpointPath != "" {
+			shard.CheckpointPath += "." + strconv.Itoa(int(id))
+		}
+		g, err := NewGenerator(shard)
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+		p.shards = append(p.shards, g)
+	}
+	return p, nil
+}
+
+// # Wrapper for NewPool(config)
+//
+// Wrapper for [NewPool] function. Creates panic if [NewPool] returns an error.
+func MustNewPool(config PoolConfig) *Pool {
+	p, err := NewPool(config)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// # Method Generate() of Pool
+//
+// Generates a new snowflake ID. Same as [Pool.NextID] with [context.Background].
+//
+// # Return
+//
+//   - [Snowflake]: New unique snowflake ID.
+//   - error
+//
+// # Errors
+//
+//   - Same errors as [Generator.Generate].
+//
+// (No arguments and examples)
+func (p *Pool) Generate() (Snowflake, error) {
+	return p.NextID(context.Background())
+}
+
+// # Method NextID(ctx) of Pool
+//
+// Generates a new snowflake ID with the next shard. If the sequence of that shard is exhausted,
+// tries the other shards; if all of them are exhausted, waits for the first shard like
+// [Generator.NextID]. Exhausted shards that are skipped do not count in [Generator.Stats] and do
+// not call [Hooks.OnSequenceExhausted]; only the exhaustion of the shard the call waits for (or
+// fails with) does.
+//
+// # Arguments
+//
+//   - ctx [context.Context]: Context that limits how long the call may wait.
+//
+// # Return
+//
+//   - [Snowflake]: New unique snowflake ID.
+//   - error
+//
+// # Errors
+//
+//   - Same errors as [Generator.NextID].
+//
+// (No examples)
+func (p *Pool) NextID(ctx context.Context) (Snowflake, error) {
+	// Shards are tried without waiting, so the context is not checked by them.
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	first := p.next.Add(1)
+
+	for i := range p.shards {
+		g := p.shards[(first+uint64(i))%uint64(len(p.shards))]
+
+		// Skipped shards do not count the exhaustion: the caller does not wait for them.
+		state, until, _, err := g.step(1, 0, true)
+		if err == nil && until != 0 {
+			continue
+		}
+		if err == nil {
+			err = g.covered(state)
+		}
+		if err != nil {
+			return 0, err
+		}
+		g.issued(state, 1)
+		return g.snowflake(state), nil
+	}
+
+	return p.shards[first%uint64(len(p.shards))].NextID(ctx)
+}
+
+// # Method Reserve(ctx, n) of Pool
+//
+// Atomically reserves a block of n snowflake IDs with the next shard. See [Generator.Reserve].
+//
+// # Arguments
+//
+//   - ctx [context.Context]: Context that limits how long the call may wait.
+//   - n int: Number of snowflake IDs to reserve.
+//
+// # Return
+//
+//   - [Range]: Reserved block of snowflake IDs.
+//   - error
+//
+// # Errors
+//
+//   - Same errors as [Generator.Reserve].
+//
+// (No examples)
+func (p *Pool) Reserve(ctx context.Context, n int) (Range, error) {
+	return p.shards[p.next.Add(1)%uint64(len(p.shards))].Reserve(ctx, n)
+}
+
+// # Wrapper for Generate()
+//
+// Wrapper for [Pool.Generate] method. Creates panic if [Pool.Generate] returns an error.
+func (p *Pool) MustGenerate() Snowflake {
+	s, err := p.Generate()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// # Method Shards() of Pool
+//
+// Returns number of generators in the pool.
+//
+// # Return
+//
+//   - int: Number of shards.
+//
+// (No arguments, errors, and examples)
+func (p *Pool) Shards() int {
+	return len(p.shards)
+}
+
+// # Method Close() of Pool
+//
+// Closes all generators of the pool. See [Generator.Close].
+//
+// # Return
+//
+//   - error
+//
+// # Errors
+//
+//   - Same errors as [Generator.Close]. Only the first error is returned.
+//
+// (No arguments and examples)
+func (p *Pool) Close() error {
+	var err error
+	for _, g := range p.shards {
+		if closeErr := g.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// # Method Stats() of Pool
+//
+// Returns statistics of all generators of the pool combined: counters are summed up, and the
+// last ID is the one with the latest creation time.
+//
+// # Return
+//
+//   - [Stats]: Pool statistics.
+//
+// (No arguments, errors, and examples)
+func (p *Pool) Stats() Stats {
+	var total Stats
+	for _, g := range p.shards {
+		s := g.Stats()
+		total.Issued += s.Issued
+		total.SequenceExhaustions += s.SequenceExhaustions
+		total.WaitTime += s.WaitTime
+		total.ClockRegressions += s.ClockRegressions
+		if s.Issued > 0 && !s.LastTime.Before(total.LastTime) {
+			total.LastID, total.LastTime = s.LastID, s.LastTime
+		}
+	}
+	return total
+}
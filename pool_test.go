@@ -0,0 +1,125 @@
+package snowflake_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/gophercord/snowflake"
+)
+
+func TestNewPool(t *testing.T) {
+	tests := []struct {
+		ProcessIDs []uint8
+		WantsErr   bool
+	}{
+		{[]uint8{0}, false},
+		{[]uint8{0, 1, 2, 31}, false},
+		{nil, true},
+		{[]uint8{1, 2, 1}, true},
+		{[]uint8{0, 32}, true},
+	}
+
+	for i, test := range tests {
+		p, err := snowflake.NewPool(snowflake.PoolConfig{ProcessIDs: test.ProcessIDs})
+
+		if (err != nil) != test.WantsErr {
+			t.Errorf("FAIL TestNewPool[%d]: process IDs %v wanted error=%v, got %v",
+				i, test.ProcessIDs, test.WantsErr, err)
+		}
+		if err == nil && p.Shards() != len(test.ProcessIDs) {
+			t.Errorf("FAIL TestNewPool[%d]: pool has %d shards, wanted %d",
+				i, p.Shards(), len(test.ProcessIDs))
+		}
+	}
+}
+
+func TestPoolNextIDCanceled(t *testing.T) {
+	p := snowflake.MustNewPool(snowflake.PoolConfig{ProcessIDs: []uint8{0, 1}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := p.NextID(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("FAIL TestPoolNextIDCanceled: wanted context.Canceled, got %v", err)
+	}
+}
+
+func TestPoolThroughput(t *testing.T) {
+	clock := snowflake.NewManualClock(start)
+	p := snowflake.MustNewPool(snowflake.PoolConfig{
+		GeneratorConfig: snowflake.GeneratorConfig{
+			WorkerID:           5,
+			Clock:              clock,
+			ExhaustionStrategy: snowflake.ExhaustionStrategyFail,
+		},
+		ProcessIDs: []uint8{3, 4, 5, 6},
+	})
+
+	// All IDs are generated within one millisecond of the frozen clock.
+	seen := make(map[snowflake.Snowflake]bool)
+	for i := 0; i < 4*(snowflake.MaxSequence+1); i++ {
+		s, err := p.Generate()
+		if err != nil {
+			t.Fatalf("FAIL TestPoolThroughput[%d]: Generate returned error %v", i, err)
+		}
+		if seen[s] || s.WorkerID() != 5 || s.ProcessID() < 3 || s.ProcessID() > 6 {
+			t.Fatalf("FAIL TestPoolThroughput[%d]: bad or duplicate snowflake %d", i, s)
+		}
+		seen[s] = true
+	}
+
+	if _, err := p.Generate(); !errors.Is(err, snowflake.ErrSequenceExhausted) {
+		t.Errorf("FAIL TestPoolThroughput: wanted ErrSequenceExhausted, got %v", err)
+	}
+	// Only the exhaustion the call failed with is counted, not the skipped shards.
+	if n := p.Stats().SequenceExhaustions; n != 1 {
+		t.Errorf("FAIL TestPoolThroughput: counted %d exhaustions, wanted 1", n)
+	}
+}
+
+func TestPoolConcurrent(t *testing.T) {
+	const goroutines, perGoroutine = 8, 5_000
+
+	p := snowflake.MustNewPool(snowflake.PoolConfig{ProcessIDs: []uint8{0, 1, 2}})
+	results := make([][]snowflake.Snowflake, goroutines)
+
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				results[i] = append(results[i], p.MustGenerate())
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[snowflake.Snowflake]bool, goroutines*perGoroutine)
+	last := make(map[uint8]snowflake.Snowflake)
+	for _, ids := range results {
+		for _, s := range ids {
+			if seen[s] {
+				t.Fatalf("FAIL TestPoolConcurrent: duplicate snowflake %d", s)
+			}
+			seen[s] = true
+		}
+	}
+
+	// Within one goroutine, IDs of the same shard are strictly increasing.
+	for i, ids := range results {
+		for k := range last {
+			delete(last, k)
+		}
+		for _, s := range ids {
+			if prev, ok := last[s.ProcessID()]; ok && s <= prev {
+				t.Fatalf("FAIL TestPoolConcurrent[%d]: snowflake %d of shard %d is not greater "+
+					"than previous %d",
+					i, s, s.ProcessID(), prev)
+			}
+			last[s.ProcessID()] = s
+		}
+	}
+}
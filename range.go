@@ -0,0 +1,146 @@
+package snowflake
+
+// Block of snowflake IDs that directly follow each other, reserved with [Generator.Reserve].
+//
+// A range only stores its first ID and its length, so it stays small no matter how many IDs it
+// holds. Use [Range.At] and [Range.Len] (or [Range.Each]) to iterate over IDs, and [Range.Slice]
+// to get all of them at once.
+type Range struct {
+	node  uint64 // Worker ID and process ID, already shifted into place.
+	first uint64 // Generator state of the first ID (timestamp<<12 | sequence).
+	n     uint64
+}
+
+// # Method Len() of Range
+//
+// Returns number of snowflake IDs in the range.
+//
+// # Return
+//
+//   - int: Number of snowflake IDs.
+//
+// (No arguments, errors, and examples)
+func (r Range) Len() int {
+	return int(r.n)
+}
+
+// # Method At(i) of Range
+//
+// Returns i-th snowflake ID of the range. IDs are in increasing order.
+//
+// # Arguments
+//
+//   - i int: Index of the snowflake ID, from 0 to [Range.Len]-1.
+//
+// # Return
+//
+//   - [Snowflake]: Snowflake ID.
+//
+// # Examples
+//
+//	r, _ := g.Reserve(context.Background(), 3)
+//	fmt.Println(r.At(0) == r.First()) // true
+//	fmt.Println(r.At(2) == r.Last())  // true
+//
+// (No errors. Panics if i is out of range)
+func (r Range) At(i int) Snowflake {
+	if i < 0 || uint64(i) >= r.n {
+		panic("snowflake: range index out of range")
+	}
+	state := r.first + uint64(i)
+	return Snowflake(state>>12<<22 | r.node | state&MaxSequence)
+}
+
+// # Method First() of Range
+//
+// Returns the first (smallest) snowflake ID of the range, or zero if the range is empty.
+//
+// # Return
+//
+//   - [Snowflake]: Snowflake ID.
+//
+// (No arguments, errors, and examples)
+func (r Range) First() Snowflake {
+	if r.n == 0 {
+		return 0
+	}
+	return r.At(0)
+}
+
+// # Method Last() of Range
+//
+// Returns the last (largest) snowflake ID of the range, or zero if the range is empty.
+//
+// # Return
+//
+//   - [Snowflake]: Snowflake ID.
+//
+// (No arguments, errors, and examples)
+func (r Range) Last() Snowflake {
+	if r.n == 0 {
+		return 0
+	}
+	return r.At(int(r.n - 1))
+}
+
+// # Method Contains(s) of Range
+//
+// Reports whether snowflake ID s belongs to the range.
+//
+// # Arguments
+//
+//   - s [Snowflake]: Snowflake ID to check.
+//
+// # Return
+//
+//   - bool: True if s is in the range.
+//
+// (No errors and examples)
+func (r Range) Contains(s Snowflake) bool {
+	if uint64(s)&(0x3E0000|0x1F000) != r.node {
+		return false
+	}
+	state := uint64(s)>>22<<12 | uint64(s)&MaxSequence
+	return state >= r.first && state-r.first < r.n
+}
+
+// # Method Each(fn) of Range
+//
+// Calls fn for every snowflake ID of the range in increasing order, until fn returns false.
+//
+// # Arguments
+//
+//   - fn func([Snowflake]) bool: Function to call. Return false to stop.
+//
+// # Examples
+//
+//	r.Each(func(s snowflake.Snowflake) bool {
+//		fmt.Println(s)
+//		return true
+//	})
+//
+// (No return and errors)
+func (r Range) Each(fn func(Snowflake) bool) {
+	for i := uint64(0); i < r.n; i++ {
+		if !fn(r.At(int(i))) {
+			return
+		}
+	}
+}
+
+// # Method Slice() of Range
+//
+// Returns all snowflake IDs of the range in increasing order.
+//
+// # Return
+//
+//   - []Snowflake: Snowflake IDs.
+//
+// (No arguments, errors, and examples)
+func (r Range) Slice() []Snowflake {
+	ids := make([]Snowflake, r.n)
+	for i := range ids {
+		ids[i] = r.At(i)
+	}
+	return ids
+}
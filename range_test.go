@@ -0,0 +1,122 @@
+package snowflake_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gophercord/snowflake"
+)
+
+func TestReserve(t *testing.T) {
+	config := snowflake.GeneratorConfig{WorkerID: 1, ProcessID: 2}
+	generators := map[string]snowflake.IDGenerator{
+		"mutex":  snowflake.MustNewGenerator(config),
+		"atomic": snowflake.MustNewAtomicGenerator(config),
+	}
+	sizes := []int{1, 2, 4096, 4097, 10_000}
+
+	for name, g := range generators {
+		for _, n := range sizes {
+			before, _ := g.Generate()
+			r, err := g.Reserve(context.Background(), n)
+			if err != nil {
+				t.Fatalf("FAIL TestReserve[%s %d]: Reserve returned error %v", name, n, err)
+			}
+			after, _ := g.Generate()
+
+			ids := r.Slice()
+			if r.Len() != n || len(ids) != n {
+				t.Fatalf("FAIL TestReserve[%s %d]: range has %d IDs (slice %d), wanted %d",
+					name, n, r.Len(), len(ids), n)
+			}
+			if ids[0] != r.First() || ids[n-1] != r.Last() {
+				t.Errorf("FAIL TestReserve[%s %d]: First/Last do not match slice", name, n)
+			}
+			if r.First() <= before || r.Last() >= after {
+				t.Errorf("FAIL TestReserve[%s %d]: range [%d, %d] is not between %d and %d",
+					name, n, r.First(), r.Last(), before, after)
+			}
+			if r.Contains(before) || r.Contains(after) {
+				t.Errorf("FAIL TestReserve[%s %d]: range contains IDs outside of it", name, n)
+			}
+
+			for i, s := range ids {
+				if !r.Contains(s) {
+					t.Fatalf("FAIL TestReserve[%s %d]: range does not contain its ID %d",
+						name, n, s)
+				}
+				if s.WorkerID() != 1 || s.ProcessID() != 2 {
+					t.Fatalf("FAIL TestReserve[%s %d]: ID %d has wrong worker or process ID",
+						name, n, s)
+				}
+				if i == 0 {
+					continue
+				}
+				prev := ids[i-1]
+				next := prev.Sequence() + 1
+				if prev.Sequence() == snowflake.MaxSequence {
+					if s.Sequence() != 0 || s.UnixMilli() != prev.UnixMilli()+1 {
+						t.Fatalf("FAIL TestReserve[%s %d]: ID %d does not directly follow %d",
+							name, n, s, prev)
+					}
+				} else if s.Sequence() != next || s.UnixMilli() != prev.UnixMilli() {
+					t.Fatalf("FAIL TestReserve[%s %d]: ID %d does not directly follow %d",
+						name, n, s, prev)
+				}
+			}
+		}
+
+		if _, err := g.Reserve(context.Background(), 0); err == nil {
+			t.Errorf("FAIL TestReserve[%s]: Reserve(0) wanted error!=nil but error IS nil", name)
+		}
+	}
+}
+
+func TestReserveNotBeforeCall(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, atomic := range []bool{false, true} {
+		clock := &steppedClock{now: start}
+		config := snowflake.GeneratorConfig{Clock: clock}
+		var g snowflake.IDGenerator = snowflake.MustNewGenerator(config)
+		if atomic {
+			g = snowflake.MustNewAtomicGenerator(config)
+		}
+
+		r, err := g.Reserve(context.Background(), 10_000)
+		if err != nil {
+			t.Fatalf("FAIL TestReserveNotBeforeCall[%t]: Reserve returned error %v", atomic, err)
+		}
+		if min, _ := snowflake.MinForTime(start); r.First() < min {
+			t.Errorf("FAIL TestReserveNotBeforeCall[%t]: first ID %d (%v) is before %d (%v)",
+				atomic, r.First(), r.First().Time(), min, start)
+		}
+		if !r.First().Time().Equal(start) {
+			t.Errorf("FAIL TestReserveNotBeforeCall[%t]: first ID has time %v, wanted %v",
+				atomic, r.First().Time(), start)
+		}
+	}
+}
+
+func TestRangeEach(t *testing.T) {
+	g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{})
+	r, _ := g.Reserve(context.Background(), 100)
+
+	var count int
+	r.Each(func(s snowflake.Snowflake) bool {
+		if s != r.At(count) {
+			t.Errorf("FAIL TestRangeEach[%d]: Each gave %d, At gave %d", count, s, r.At(count))
+		}
+		count++
+		return count < 10
+	})
+
+	if count != 10 {
+		t.Errorf("FAIL TestRangeEach: Each did not stop after fn returned false (count=%d)", count)
+	}
+
+	ids, err := g.GenerateN(5)
+	if err != nil || len(ids) != 5 || ids[0] <= r.Last() {
+		t.Errorf("FAIL TestRangeEach: GenerateN(5) returned %v, %v", ids, err)
+	}
+}
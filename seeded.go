@@ -0,0 +1,259 @@
+package snowflake
+
+import (
+	"sync"
+	"time"
+)
+
+// Default value of [SeededConfig.MaxStep].
+const DefaultSeededMaxStep = time.Second
+
+// Largest random starting sequence of a time unit. Leaves at least 3072 sequence numbers for the
+// rest of the time unit.
+const seededSequenceStart = 1023
+
+// Configuration of a [SeededGenerator]. Passed to [NewSeededGenerator].
+type SeededConfig struct {
+	// Seed of the pseudo-random stream. The same seed and configuration always give the same
+	// snowflake IDs.
+	Seed uint64
+
+	// Creation time of the first snowflake ID. If zero, 2020-01-01 00:00:00 UTC is used.
+	Start time.Time
+
+	// A Unix timestamp in milliseconds used as the generator epoch. If zero, the value of
+	// [Epoch] at the moment of calling [NewSeededGenerator] is used.
+	Epoch uint64
+
+	// Duration of one timestamp tick, the time unit. If zero, one millisecond is used.
+	TimeUnit time.Duration
+
+	// Maximum time between two consecutive snowflake IDs. Every ID is created at a random time
+	// from 0 to MaxStep after the previous one, in whole time units. If zero,
+	// [DefaultSeededMaxStep] is used.
+	MaxStep time.Duration
+}
+
+// Deterministic generator of realistic looking snowflake IDs, for fixtures, snapshot tests and
+// demo datasets. Safe for concurrent use by multiple goroutines, but the stream is reproducible
+// only if IDs are requested in the same order.
+//
+// Snowflake IDs of a seeded generator are unique and strictly increasing. Their creation times
+// start at the configured time and move forward by random steps, their worker IDs and process
+// IDs are random for every time unit, and their sequence starts at a random value from 0 to 1023
+// in every time unit and increases while the creation time stays the same. The stream does not
+// depend on the system clock at all.
+//
+// Create seeded generators with [NewSeededGenerator]; the zero value is not usable.
+type SeededGenerator struct {
+	mu      sync.Mutex
+	config  SeededConfig
+	start   uint64 // Timestamp of Start, since Epoch.
+	maxStep uint64 // In time units.
+
+	rand uint64 // State of the pseudo-random generator.
+	last uint64 // Timestamp and sequence of the last ID, packed as timestamp<<12 | sequence.
+	node uint64 // Worker ID and process ID of the last ID.
+	used bool   // Whether any ID was generated since the last reset.
+}
+
+// # Function NewSeededGenerator(config)
+//
+// Creates a new deterministic snowflake ID generator.
+//
+// # Arguments
+//
+//   - config [SeededConfig]: Generator configuration.
+//
+// # Return
+//
+//   - *[SeededGenerator]: New generator.
+//   - error
+//
+// # Errors
+//
+//   - [TimeBeforeEpochError]: If the start time is before the epoch.
+//   - [TimeOverflowError]: If the start time does not fit into 42 bits.
+//   - [SnowflakeError]: If the time unit is negative.
+//
+// # Examples
+//
+//	a := snowflake.MustNewSeededGenerator(snowflake.SeededConfig{Seed: 42})
+//	b := snowflake.MustNewSeededGenerator(snowflake.SeededConfig{Seed: 42})
+//	fmt.Println(a.MustGenerate() == b.MustGenerate()) // true
+func NewSeededGenerator(config SeededConfig) (*SeededGenerator, error) {
+	if config.Start.IsZero() {
+		config.Start = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	if config.Epoch == 0 {
+		config.Epoch = Epoch
+	}
+	if config.MaxStep <= 0 {
+		config.MaxStep = DefaultSeededMaxStep
+	}
+
+	unit, err := timeUnit(config.TimeUnit)
+	if err != nil {
+		return nil, err
+	}
+	start, err := timeBase{epoch: config.Epoch, unit: unit}.ticks(config.Start, MaxTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &SeededGenerator{
+		config:  config,
+		start:   start,
+		maxStep: uint64(config.MaxStep / unit),
+	}
+	g.Reset()
+	return g, nil
+}
+
+// # Wrapper for NewSeededGenerator(config)
+//
+// Wrapper for [NewSeededGenerator] function. Creates panic if [NewSeededGenerator] returns an
+// error.
+func MustNewSeededGenerator(config SeededConfig) *SeededGenerator {
+	g, err := NewSeededGenerator(config)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// # Method Generate() of SeededGenerator
+//
+// Generates the next snowflake ID of the stream.
+//
+// # Return
+//
+//   - [Snowflake]: Next snowflake ID.
+//   - error
+//
+// # Errors
+//
+//   - [TimeOverflowError]: If the stream has moved past the 42-bit timestamp range.
+//
+// (No arguments and examples)
+func (g *SeededGenerator) Generate() (Snowflake, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	r := g.next()
+	state := g.start << 12
+	if g.used {
+		state = g.last + 1
+		if step := r % (g.maxStep + 1); step > 0 {
+			state = (g.last>>12 + step) << 12
+		}
+	}
+	if !g.used || state>>12 != g.last>>12 {
+		// New time unit: new node and a random starting sequence. The high bits of the random
+		// value are not used by the step.
+		g.node = r >> 54
+		state = state>>12<<12 | r>>44&seededSequenceStart
+	}
+	if state>>12 > MaxTimestamp {
+		return 0, &TimeOverflowError{SnowflakeError: SnowflakeError{
+			message: "seeded stream has moved past the end of time",
+		}}
+	}
+	g.last, g.used = state, true
+	return Snowflake(state>>12<<22 | g.node<<12 | state&MaxSequence), nil
+}
+
+// # Wrapper for Generate()
+//
+// Wrapper for [SeededGenerator.Generate] method. Creates panic if [SeededGenerator.Generate]
+// returns an error.
+func (g *SeededGenerator) MustGenerate() Snowflake {
+	s, err := g.Generate()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// # Method GenerateN(n) of SeededGenerator
+//
+// Generates the next n snowflake IDs of the stream.
+//
+// # Arguments
+//
+//   - n int: Number of snowflake IDs.
+//
+// # Return
+//
+//   - []Snowflake: Next snowflake IDs.
+//   - error
+//
+// # Errors
+//
+//   - Same errors as [SeededGenerator.Generate].
+//
+// (No examples)
+func (g *SeededGenerator) GenerateN(n int) ([]Snowflake, error) {
+	ids := make([]Snowflake, 0, n)
+	for i := 0; i < n; i++ {
+		s, err := g.Generate()
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, s)
+	}
+	return ids, nil
+}
+
+// # Method Reset() of SeededGenerator
+//
+// Restarts the stream from the beginning: the next snowflake ID is the same as the first ID
+// generated after creation.
+//
+// (No arguments, return, errors, and examples)
+func (g *SeededGenerator) Reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.rand, g.last, g.node, g.used = g.config.Seed, 0, 0, false
+}
+
+// # Method Fork() of SeededGenerator
+//
+// Returns a copy of the generator at its current position. The copy and the original produce
+// the same snowflake IDs from now on, independently of each other. Resetting the copy restarts
+// it from the beginning of the stream, like the original.
+//
+// # Return
+//
+//   - *[SeededGenerator]: Copy of the generator.
+//
+// # Examples
+//
+//	g := snowflake.MustNewSeededGenerator(snowflake.SeededConfig{Seed: 1})
+//	g.GenerateN(100)
+//	fork := g.Fork()
+//	fmt.Println(g.MustGenerate() == fork.MustGenerate()) // true
+//
+// (No arguments and errors)
+func (g *SeededGenerator) Fork() *SeededGenerator {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return &SeededGenerator{
+		config:  g.config,
+		start:   g.start,
+		maxStep: g.maxStep,
+		rand:    g.rand,
+		last:    g.last,
+		node:    g.node,
+		used:    g.used,
+	}
+}
+
+// Returns the next pseudo-random number (SplitMix64).
+func (g *SeededGenerator) next() uint64 {
+	g.rand += 0x9E3779B97F4A7C15
+	z := g.rand
+	z = (z ^ z>>30) * 0xBF58476D1CE4E5B9
+	z = (z ^ z>>27) * 0x94D049BB133111EB
+	return z ^ z>>31
+}
@@ -0,0 +1,140 @@
+package snowflake_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gophercord/snowflake"
+)
+
+func TestSeededGeneratorDeterministic(t *testing.T) {
+	config := snowflake.SeededConfig{Seed: 42, Start: start}
+	a := snowflake.MustNewSeededGenerator(config)
+	b := snowflake.MustNewSeededGenerator(config)
+
+	ids, err := a.GenerateN(10000)
+	if err != nil {
+		t.Fatalf("FAIL TestSeededGeneratorDeterministic: unexpected error: %v", err)
+	}
+	for i, want := range ids {
+		if got := b.MustGenerate(); got != want {
+			t.Fatalf("FAIL TestSeededGeneratorDeterministic[%d]: got %d, want %d", i, got, want)
+		}
+	}
+
+	if got := ids[0].Time(); !got.Equal(start) {
+		t.Errorf("FAIL TestSeededGeneratorDeterministic: first time %s, want %s", got, start)
+	}
+
+	workers := map[uint8]bool{}
+	for i := 1; i < len(ids); i++ {
+		if ids[i] <= ids[i-1] {
+			t.Fatalf("FAIL TestSeededGeneratorDeterministic[%d]: %d is not after %d",
+				i, ids[i], ids[i-1])
+		}
+		if step := ids[i].Time().Sub(ids[i-1].Time()); step > snowflake.DefaultSeededMaxStep {
+			t.Fatalf("FAIL TestSeededGeneratorDeterministic[%d]: step %s", i, step)
+		}
+		workers[ids[i].WorkerID()] = true
+	}
+	if len(workers) < 2 {
+		t.Errorf("FAIL TestSeededGeneratorDeterministic: worker IDs do not vary")
+	}
+
+	other := snowflake.MustNewSeededGenerator(snowflake.SeededConfig{Seed: 43, Start: start})
+	if other.MustGenerate() == ids[0] && other.MustGenerate() == ids[1] {
+		t.Errorf("FAIL TestSeededGeneratorDeterministic: different seeds give the same stream")
+	}
+}
+
+func TestSeededGeneratorSequence(t *testing.T) {
+	g := snowflake.MustNewSeededGenerator(snowflake.SeededConfig{
+		Seed:    7,
+		Start:   start,
+		MaxStep: time.Millisecond,
+	})
+
+	ids, _ := g.GenerateN(1000)
+	same := 0
+	for i := 1; i < len(ids); i++ {
+		if ids[i].UnixMilli() != ids[i-1].UnixMilli() {
+			continue
+		}
+		same++
+		if ids[i].Sequence() != ids[i-1].Sequence()+1 {
+			t.Errorf("FAIL TestSeededGeneratorSequence[%d]: sequence %d after %d",
+				i, ids[i].Sequence(), ids[i-1].Sequence())
+		}
+		if ids[i].WorkerID() != ids[i-1].WorkerID() ||
+			ids[i].ProcessID() != ids[i-1].ProcessID() {
+			t.Errorf("FAIL TestSeededGeneratorSequence[%d]: node changed within millisecond", i)
+		}
+	}
+	if same == 0 {
+		t.Errorf("FAIL TestSeededGeneratorSequence: no IDs share a millisecond")
+	}
+}
+
+func TestSeededGeneratorStartSequence(t *testing.T) {
+	starts := map[uint16]bool{}
+	for seed := uint64(0); seed < 20; seed++ {
+		g := snowflake.MustNewSeededGenerator(snowflake.SeededConfig{Seed: seed, Start: start})
+		ids, _ := g.GenerateN(100)
+		for i, id := range ids {
+			if i > 0 && id.UnixMilli() == ids[i-1].UnixMilli() {
+				continue
+			}
+			if id.Sequence() > 1023 {
+				t.Errorf("FAIL TestSeededGeneratorStartSequence[%d]: starting sequence %d",
+					seed, id.Sequence())
+			}
+			starts[id.Sequence()] = true
+		}
+	}
+	if len(starts) < 100 {
+		t.Errorf("FAIL TestSeededGeneratorStartSequence: only %d different starting sequences",
+			len(starts))
+	}
+}
+
+func TestSeededGeneratorResetFork(t *testing.T) {
+	g := snowflake.MustNewSeededGenerator(snowflake.SeededConfig{Seed: 1, Start: start})
+	first, _ := g.GenerateN(100)
+
+	fork := g.Fork()
+	next, _ := g.GenerateN(100)
+	forked, _ := fork.GenerateN(100)
+	for i := range next {
+		if next[i] != forked[i] {
+			t.Fatalf("FAIL TestSeededGeneratorResetFork[%d]: fork got %d, want %d",
+				i, forked[i], next[i])
+		}
+	}
+
+	g.Reset()
+	again, _ := g.GenerateN(100)
+	for i := range first {
+		if first[i] != again[i] {
+			t.Fatalf("FAIL TestSeededGeneratorResetFork[%d]: after reset got %d, want %d",
+				i, again[i], first[i])
+		}
+	}
+}
+
+func TestNewSeededGeneratorErrors(t *testing.T) {
+	tests := []struct {
+		start time.Time
+		err   error
+	}{
+		{time.UnixMilli(int64(snowflake.Epoch) - 1), &snowflake.TimeBeforeEpochError{}},
+		{time.UnixMilli(int64(snowflake.Epoch + snowflake.MaxTimestamp + 1)),
+			&snowflake.TimeOverflowError{}},
+	}
+
+	for i, test := range tests {
+		_, err := snowflake.NewSeededGenerator(snowflake.SeededConfig{Start: test.start})
+		if !sameErrorType(err, test.err) {
+			t.Errorf("FAIL TestNewSeededGeneratorErrors[%d]: got %T, want %T", i, err, test.err)
+		}
+	}
+}
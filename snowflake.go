@@ -43,7 +43,23 @@ var (
 	//
 	//	// You can change epoch if needed
 	//	snowflake.Epoch = 12345
-	Epoch uint64 = 1420070400000
+	//
+	// Changing the epoch affects every caller in the binary and is not safe while other
+	// goroutines decode snowflake IDs. Prefer a [Decoder] with its own epoch.
+	Epoch uint64 = DiscordEpoch
+)
+
+const (
+	// A Unix timestamp in milliseconds, represents the Discord epoch date and time. Default
+	// value of [Epoch].
+	DiscordEpoch = 1420070400000
+
+	MaxWorkerID  = 0x1F  // Maximum internal worker ID (5 bits).
+	MaxProcessID = 0x1F  // Maximum internal process ID (5 bits).
+	MaxSequence  = 0xFFF // Maximum sequence number (12 bits).
+
+	// Maximum number of milliseconds since epoch that fits into a snowflake (42 bits).
+	MaxTimestamp = 1<<42 - 1
 )
 
 type (
@@ -64,6 +80,8 @@ type (
 	//  2. Bits 12-17 is a internal process ID;
 	//  3. Bits 17-22 is a internal worker ID;
 	//  4. Bits 22-64 is a number of milliseconds since Discord epoch.
+	//
+	// This split is described by [LayoutDiscord]; use a [Layout] to work with other schemes.
 	Snowflake uint64
 	Bit       bool    // One bit as a bool (where 1 is true and 0 is false).
 	Bitmap    [64]Bit // List with length 64 of snowflake ID bits.
@@ -71,7 +89,8 @@ type (
 
 // # Method Unix() of Snowflake
 //
-// Returns snowflake creation date and time as milliseconds in unix format.
+// Returns snowflake creation date and time as milliseconds in unix format. Reads the
+// package-level [Epoch]; see [Decoder.UnixMilli] for a version with its own epoch.
 //
 // Calculation formula:
 //
@@ -83,12 +102,13 @@ type (
 //
 // (No arguments, errors, and examples)
 func (s Snowflake) UnixMilli() uint64 {
-	return uint64(s>>22) + Epoch
+	return discord.Timestamp(s) + Epoch
 }
 
 // # Method Unix() of Snowflake
 //
-// Returns snowflake creation date and time as seconds in unix format.
+// Returns snowflake creation date and time as seconds in unix format. Reads the package-level
+// [Epoch]; see [Decoder.Unix] for a version with its own epoch.
 //
 // Calculation formula:
 //
@@ -100,12 +120,13 @@ func (s Snowflake) UnixMilli() uint64 {
 //
 // (No arguments, errors, and examples)
 func (s Snowflake) Unix() uint64 {
-	return (uint64(s>>22) + Epoch) / 1_000
+	return (discord.Timestamp(s) + Epoch) / 1_000
 }
 
 // # Method Time() of Snowflake
 //
-// Returns snowflake creation date and time.
+// Returns snowflake creation date and time. Reads the package-level [Epoch]; see [Decoder.Time]
+// for a version with its own epoch.
 //
 // # Return
 //
@@ -120,7 +141,7 @@ func (s Snowflake) Unix() uint64 {
 //
 // (No arguments and errors)
 func (s Snowflake) Time() time.Time {
-	return time.UnixMilli(int64(s>>22) + int64(Epoch))
+	return time.UnixMilli(int64(discord.Timestamp(s) + Epoch))
 }
 
 // # Method WorkerID() of Snowflake
@@ -144,7 +165,7 @@ func (s Snowflake) Time() time.Time {
 //
 // (No arguments and errors)
 func (s Snowflake) WorkerID() uint8 {
-	return uint8((s & 0x3E0000) >> 17)
+	return uint8(discord.field(s, 0))
 }
 
 // # Method ProcessID() of Snowflake
@@ -168,7 +189,7 @@ func (s Snowflake) WorkerID() uint8 {
 //
 // (No arguments and errors)
 func (s Snowflake) ProcessID() uint8 {
-	return uint8((s & 0x1F000) >> 12)
+	return uint8(discord.field(s, 1))
 }
 
 // # Method Sequence() of Snowflake
@@ -192,7 +213,7 @@ func (s Snowflake) ProcessID() uint8 {
 //
 // (No arguments and errors)
 func (s Snowflake) Sequence() uint16 {
-	return uint16(s & 0xFFF)
+	return uint16(discord.field(s, 2))
 }
 
 // # Method String() of Snowflake
@@ -387,7 +408,12 @@ func MustParseString(s string) Snowflake {
 // # Function ParseTime(t)
 //
 // Creates a new snowflake ID based on a [time.Time] with zero worker ID and process ID, and a
-// sequence.
+// sequence. Reads the package-level [Epoch]; see [Decoder.ParseTime] for a version with its own
+// epoch.
+//
+// Times outside the range from [MinTime] to [MaxTime] are not checked: a time before the epoch
+// gives a huge bogus snowflake ID and a later time wraps around. Use [ParseTimeChecked] to get
+// an error instead.
 //
 // # Arguments
 //
@@ -415,6 +441,58 @@ func ParseTime(t time.Time) Snowflake {
 	return Snowflake((t.UnixMilli() - int64(Epoch)) << 22)
 }
 
+// # Function ParseTimeChecked(t)
+//
+// Creates a new snowflake ID based on a [time.Time] with zero worker ID, process ID and sequence,
+// like [ParseTime], but rejects times that do not fit into a snowflake ID. Sub-millisecond parts
+// of t are dropped. Reads the package-level [Epoch].
+//
+// # Arguments
+//
+//   - t [time.Time]: Time from which to parse a new snowflake ID.
+//
+// # Return
+//
+//   - [Snowflake]: New snowflake parsed from argument "t".
+//   - error
+//
+// # Errors
+//
+//   - [TimeBeforeEpochError]: If t is before [MinTime].
+//   - [TimeOverflowError]: If t is in a millisecond after [MaxTime] (the 42-bit timestamp would
+//     overflow).
+//
+// # Examples
+//
+//	_, err := snowflake.ParseTimeChecked(time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC))
+//	fmt.Println(err) // time 2010-01-01 00:00:00 +0000 UTC is before epoch 1420070400000
+func ParseTimeChecked(t time.Time) (Snowflake, error) {
+	return Decoder{Epoch: Epoch}.ParseTimeChecked(t)
+}
+
+// # Function MinTime()
+//
+// Returns the earliest date and time a snowflake ID can represent: the package-level [Epoch].
+//
+// (No arguments, errors, and examples)
+func MinTime() time.Time {
+	return Decoder{Epoch: Epoch}.MinTime()
+}
+
+// # Function MaxTime()
+//
+// Returns the latest date and time a snowflake ID can represent with the package-level [Epoch]:
+// creation time of snowflake IDs with the largest 42-bit timestamp.
+//
+// # Examples
+//
+//	fmt.Println(snowflake.MaxTime().UTC()) // 2154-05-15 07:35:11.103 +0000 UTC
+//
+// (No arguments and errors)
+func MaxTime() time.Time {
+	return Decoder{Epoch: Epoch}.MaxTime()
+}
+
 // # Function ParseJSON(b)
 //
 // Parses a new snowflake from a JSON-formatted string (must be encoded as bytes). Can be an
@@ -534,6 +612,8 @@ func MustParse[T string | uint64 | time.Time](v T) Snowflake {
 //
 // Creates a new snowflake ID with all bits set to zero. You can also use Snowflake(0).
 //
+// To create new unique snowflake IDs, use [Generator] instead.
+//
 // # Return
 //
 //   - [Snowflake]: New snowflake with all bits set to zero.
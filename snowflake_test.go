@@ -2,6 +2,7 @@ package snowflake_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/gophercord/snowflake"
 )
@@ -167,3 +168,49 @@ func TestUnmarshalJSON(t *testing.T) {
 
 	// No more tests needed for UnmarshalJSON, because UnmarshalJSON is based on ParseJSON
 }
+
+func TestParseTimeChecked(t *testing.T) {
+	tests := []struct {
+		t    time.Time
+		want snowflake.Snowflake
+		err  error
+	}{
+		{example.Time(), example >> 22 << 22, nil},
+		{example.Time().Add(999 * time.Microsecond), example >> 22 << 22, nil},
+		{snowflake.MinTime(), 0, nil},
+		{snowflake.MaxTime(), snowflake.MaxTimestamp << 22, nil},
+		{snowflake.MaxTime().Add(time.Millisecond - 1), snowflake.MaxTimestamp << 22, nil},
+		{snowflake.MinTime().Add(-time.Nanosecond), 0, &snowflake.TimeBeforeEpochError{}},
+		{time.Time{}, 0, &snowflake.TimeBeforeEpochError{}},
+		{snowflake.MaxTime().Add(time.Millisecond), 0, &snowflake.TimeOverflowError{}},
+	}
+
+	for i, test := range tests {
+		s, err := snowflake.ParseTimeChecked(test.t)
+		if !sameErrorType(err, test.err) || s != test.want {
+			t.Errorf("FAIL TestParseTimeChecked[%d]: got %d, %v, want %d, %T",
+				i, s, err, test.want, test.err)
+		}
+	}
+
+	if got := snowflake.MinTime(); !got.Equal(time.UnixMilli(snowflake.DiscordEpoch)) {
+		t.Errorf("FAIL TestParseTimeChecked: MinTime %s", got)
+	}
+	want := time.Date(2154, 5, 15, 7, 35, 11, 103e6, time.UTC)
+	if got := snowflake.MaxTime(); !got.Equal(want) {
+		t.Errorf("FAIL TestParseTimeChecked: MaxTime %s, want %s", got, want)
+	}
+
+	d := snowflake.Decoder{Epoch: 1000, TimeUnit: time.Second}
+	if _, err := d.ParseTimeChecked(d.MaxTime().Add(time.Second)); err == nil {
+		t.Errorf("FAIL TestParseTimeChecked: decoder accepted time after MaxTime")
+	}
+	if s, err := d.ParseTimeChecked(d.MinTime()); err != nil || s != 0 {
+		t.Errorf("FAIL TestParseTimeChecked: decoder MinTime gave %d, %v", s, err)
+	}
+
+	negative := snowflake.Decoder{TimeUnit: -time.Second}
+	if _, err := negative.ParseTimeChecked(time.Now()); err == nil {
+		t.Errorf("FAIL TestParseTimeChecked: decoder accepted negative time unit")
+	}
+}
@@ -0,0 +1,40 @@
+// Publishes statistics of gophercord/snowflake generators with the standard expvar package.
+//
+// This is a separate package because importing expvar registers the /debug/vars handler on
+// [net/http.DefaultServeMux]: only programs that import this package get it.
+package snowflakeexpvar
+
+import (
+	"expvar"
+
+	"github.com/gophercord/snowflake"
+)
+
+// Source of statistics: [snowflake.Generator], [snowflake.AtomicGenerator] or [snowflake.Pool].
+type StatsSource interface {
+	Stats() snowflake.Stats
+}
+
+// # Function Publish(name, source)
+//
+// Publishes statistics of source as expvar variable name. The statistics are read every time
+// the variable is requested, for example on /debug/vars.
+//
+// # Arguments
+//
+//   - name string: Name of the expvar variable. Must be unique in the program.
+//   - source [StatsSource]: Generator or pool.
+//
+// # Examples
+//
+//	g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{})
+//	snowflakeexpvar.Publish("snowflake", g)
+//	// GET /debug/vars
+//	// {"snowflake": {"issued": 42, "sequence_exhaustions": 0, ...}, ...}
+//
+// (No return and errors. Panics if the name is already used, like [expvar.Publish])
+func Publish(name string, source StatsSource) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return source.Stats()
+	}))
+}
@@ -0,0 +1,36 @@
+package snowflakeexpvar_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gophercord/snowflake"
+	"github.com/gophercord/snowflake/snowflakeexpvar"
+)
+
+// Number of published variables. expvar names can not be reused, so every run of a test (for
+// example with -count) publishes under a new name.
+var published atomic.Int64
+
+func TestPublish(t *testing.T) {
+	name := fmt.Sprintf("snowflake_test_%d", published.Add(1))
+	g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{})
+	snowflakeexpvar.Publish(name, g)
+	s := g.MustGenerate()
+
+	var stats struct {
+		Issued uint64 `json:"issued"`
+		LastID string `json:"last_id"`
+	}
+	v := expvar.Get(name)
+	if v == nil || json.Unmarshal([]byte(v.String()), &stats) != nil {
+		t.Fatalf("FAIL TestPublish: variable is not published or is not JSON (%v)", v)
+	}
+
+	if stats.Issued != 1 || stats.LastID != s.String() {
+		t.Errorf("FAIL TestPublish: got %+v, wanted 1 issued ID %d", stats, s)
+	}
+}
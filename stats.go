@@ -0,0 +1,64 @@
+package snowflake
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Snapshot of generator statistics, returned by [Generator.Stats]. Counters start at zero when
+// the generator is created.
+type Stats struct {
+	// Number of generated snowflake IDs, including IDs of reserved blocks.
+	Issued uint64 `json:"issued"`
+	// How many times all sequence numbers of a time unit were used.
+	SequenceExhaustions uint64 `json:"sequence_exhaustions"`
+	// Total time callers spent waiting for the clock.
+	WaitTime time.Duration `json:"wait_time_ns"`
+	// How many times the clock moved backwards.
+	ClockRegressions uint64 `json:"clock_regressions"`
+	// Last generated snowflake ID, or zero if there is none yet.
+	LastID Snowflake `json:"last_id"`
+	// Creation time of LastID, or zero time if there is no ID yet.
+	LastTime time.Time `json:"last_time"`
+}
+
+type generatorStats struct {
+	issued      atomic.Uint64
+	exhaustions atomic.Uint64
+	wait        atomic.Int64
+	regressions atomic.Uint64
+	lastID      atomic.Uint64
+}
+
+// # Method Stats() of Generator
+//
+// Returns snapshot of generator statistics. Counters are read one by one, so while other
+// goroutines are generating IDs they may be slightly out of sync with each other.
+//
+// # Return
+//
+//   - [Stats]: Generator statistics.
+//
+// (No arguments, errors, and examples)
+func (g *generator) Stats() Stats {
+	s := Stats{
+		Issued:              g.stats.issued.Load(),
+		SequenceExhaustions: g.stats.exhaustions.Load(),
+		WaitTime:            time.Duration(g.stats.wait.Load()),
+		ClockRegressions:    g.stats.regressions.Load(),
+		LastID:              Snowflake(g.stats.lastID.Load()),
+	}
+	if s.Issued > 0 {
+		s.LastTime = g.base.time(uint64(s.LastID >> 22))
+	}
+	return s
+}
+
+// Records that a block of n states ending with state was generated.
+func (g *generator) issued(state, n uint64) {
+	g.stats.issued.Add(n)
+	g.stats.lastID.Store(uint64(g.snowflake(state)))
+	if g.hooks != nil {
+		g.hooks.OnIssue(Range{node: g.node, first: state - n + 1, n: n})
+	}
+}
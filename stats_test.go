@@ -0,0 +1,59 @@
+package snowflake_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gophercord/snowflake"
+)
+
+func TestStats(t *testing.T) {
+	clock := snowflake.NewManualClock(start)
+	g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{
+		Clock:              clock,
+		ClockPolicy:        snowflake.ClockPolicyLogical,
+		ExhaustionStrategy: snowflake.ExhaustionStrategyFail,
+	})
+
+	if s := g.Stats(); s != (snowflake.Stats{}) {
+		t.Errorf("FAIL TestStats: new generator has stats %+v", s)
+	}
+
+	g.MustGenerate()
+	r, _ := g.Reserve(context.Background(), 10)
+	if _, err := g.Reserve(context.Background(), snowflake.MaxSequence); !errors.Is(err,
+		snowflake.ErrSequenceExhausted) {
+		t.Fatalf("FAIL TestStats: wanted ErrSequenceExhausted, got %v", err)
+	}
+	clock.Advance(-time.Millisecond)
+	last := g.MustGenerate()
+
+	s := g.Stats()
+	if s.Issued != 12 || s.SequenceExhaustions != 1 || s.ClockRegressions != 1 {
+		t.Errorf("FAIL TestStats: got counters %+v, wanted 12 issued, 1 exhaustion and 1 "+
+			"regression",
+			s)
+	}
+	if s.LastID != last || last <= r.Last() || !s.LastTime.Equal(start) {
+		t.Errorf("FAIL TestStats: last ID %d at %s, wanted %d at %s",
+			s.LastID, s.LastTime, last, start)
+	}
+}
+
+func TestStatsWaitTime(t *testing.T) {
+	clock := snowflake.NewManualClock(start)
+	g := snowflake.MustNewAtomicGenerator(snowflake.GeneratorConfig{Clock: clock})
+
+	g.Reserve(context.Background(), snowflake.MaxSequence+1)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		clock.Advance(time.Millisecond)
+	}()
+	g.MustGenerate()
+
+	if s := g.Stats(); s.WaitTime < 10*time.Millisecond || s.SequenceExhaustions != 1 {
+		t.Errorf("FAIL TestStatsWaitTime: wanted wait time and exhaustion, got %+v", s)
+	}
+}
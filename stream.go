@@ -0,0 +1,119 @@
+package snowflake
+
+import "context"
+
+// # Function Stream(ctx, gen, buffer)
+//
+// Starts a background goroutine that generates snowflake IDs with gen and sends them to the
+// returned channel. Up to buffer IDs are generated ahead of time, so a consumer reading from the
+// channel in a select loop rarely has to wait for the generator.
+//
+// The channel is closed when ctx is done or when gen returns an error. IDs already in the buffer
+// can still be received after that, until the channel is drained. A consumer that drains the
+// channel loses at most one ID: the one the goroutine was trying to send when ctx was done. A
+// consumer that stops reading when ctx is done loses up to buffer+1 IDs: the buffered ones and
+// that one. Snowflake IDs are never reused, so lost IDs only leave a gap.
+//
+// Errors are not reported by the stream; use [Hooks] or call gen directly to observe them. With
+// [ExhaustionStrategyFail] or [ClockPolicyError] the stream closes on the first exhausted
+// sequence or clock regression.
+//
+// # Arguments
+//
+//   - ctx [context.Context]: Context that stops the stream.
+//   - gen [IDGenerator]: Generator of snowflake IDs.
+//   - buffer int: Number of prefetched snowflake IDs. Values below zero are treated as zero.
+//
+// # Return
+//
+//   - <-chan [Snowflake]: Stream of new unique snowflake IDs in the order they were generated.
+//
+// # Examples
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	defer cancel()
+//	// The loop ends when the stream is closed: after cancel, or if gen fails.
+//	for id := range snowflake.Stream(ctx, gen, 64) {
+//		fmt.Println(id)
+//	}
+//
+// (No errors)
+func Stream(ctx context.Context, gen IDGenerator, buffer int) <-chan Snowflake {
+	if buffer < 0 {
+		buffer = 0
+	}
+	ch := make(chan Snowflake, buffer)
+
+	go func() {
+		defer close(ch)
+		for ctx.Err() == nil {
+			s, err := gen.NextID(ctx)
+			if err != nil {
+				return
+			}
+			select {
+			case ch <- s:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// # Method Stream(ctx, buffer) of Generator
+//
+// Returns a channel of new snowflake IDs, prefetched by a background goroutine. Same as
+// [Stream] with this generator.
+//
+// # Arguments
+//
+//   - ctx [context.Context]: Context that stops the stream.
+//   - buffer int: Number of prefetched snowflake IDs.
+//
+// # Return
+//
+//   - <-chan [Snowflake]: Stream of new unique snowflake IDs in increasing order.
+//
+// (No errors and examples)
+func (g *Generator) Stream(ctx context.Context, buffer int) <-chan Snowflake {
+	return Stream(ctx, g, buffer)
+}
+
+// # Method Stream(ctx, buffer) of AtomicGenerator
+//
+// Returns a channel of new snowflake IDs, prefetched by a background goroutine. Same as
+// [Stream] with this generator.
+//
+// # Arguments
+//
+//   - ctx [context.Context]: Context that stops the stream.
+//   - buffer int: Number of prefetched snowflake IDs.
+//
+// # Return
+//
+//   - <-chan [Snowflake]: Stream of new unique snowflake IDs in increasing order.
+//
+// (No errors and examples)
+func (g *AtomicGenerator) Stream(ctx context.Context, buffer int) <-chan Snowflake {
+	return Stream(ctx, g, buffer)
+}
+
+// # Method Stream(ctx, buffer) of Pool
+//
+// Returns a channel of new snowflake IDs, prefetched by a background goroutine. Same as
+// [Stream] with this pool.
+//
+// # Arguments
+//
+//   - ctx [context.Context]: Context that stops the stream.
+//   - buffer int: Number of prefetched snowflake IDs.
+//
+// # Return
+//
+//   - <-chan [Snowflake]: Stream of new unique snowflake IDs.
+//
+// (No errors and examples)
+func (p *Pool) Stream(ctx context.Context, buffer int) <-chan Snowflake {
+	return Stream(ctx, p, buffer)
+}
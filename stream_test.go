@@ -0,0 +1,63 @@
+package snowflake_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gophercord/snowflake"
+)
+
+func TestStream(t *testing.T) {
+	g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	ids := g.Stream(ctx, 8)
+
+	var prev snowflake.Snowflake
+	delivered := uint64(0)
+	for i := 0; i < 100; i++ {
+		s := <-ids
+		if s <= prev {
+			t.Fatalf("FAIL TestStream[%d]: %d is not after %d", i, s, prev)
+		}
+		prev = s
+		delivered++
+	}
+
+	cancel()
+	for s := range ids {
+		if s <= prev {
+			t.Fatalf("FAIL TestStream: drained %d is not after %d", s, prev)
+		}
+		prev = s
+		delivered++
+	}
+
+	// At most one ID can be generated but not delivered.
+	if issued := g.Stats().Issued; issued-delivered > 1 {
+		t.Errorf("FAIL TestStream: issued %d, delivered %d", issued, delivered)
+	}
+}
+
+func TestStreamError(t *testing.T) {
+	g := snowflake.MustNewAtomicGenerator(snowflake.GeneratorConfig{
+		Clock:              snowflake.NewManualClock(start),
+		ExhaustionStrategy: snowflake.ExhaustionStrategyFail,
+	})
+
+	n := 0
+	for range g.Stream(context.Background(), 16) {
+		n++
+	}
+	if n != snowflake.MaxSequence+1 {
+		t.Errorf("FAIL TestStreamError: got %d IDs, want %d", n, snowflake.MaxSequence+1)
+	}
+}
+
+func TestStreamCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := snowflake.MustNewPool(snowflake.PoolConfig{ProcessIDs: []uint8{0, 1}})
+	for range p.Stream(ctx, 0) {
+	}
+}
@@ -0,0 +1,110 @@
+package snowflake
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"time"
+)
+
+// Largest number of seconds since the Unix epoch that [time.Unix] accepts without overflowing
+// its internal representation (seconds since year 1).
+const maxUnixSeconds = math.MaxInt64 - 62135596800
+
+// Conversion between times and timestamps counted in time units since an epoch. Conversions are
+// exact: they use 128-bit intermediate values instead of floating point or overflowing products.
+type timeBase struct {
+	epoch uint64        // Unix timestamp in milliseconds.
+	unit  time.Duration // Positive.
+}
+
+// Returns the number of nanoseconds between the Unix epoch and timestamp ticks, as a 128-bit
+// value.
+func (b timeBase) nanos(ticks uint64) (hi, lo uint64) {
+	hi, lo = bits.Mul64(ticks, uint64(b.unit))
+	ehi, elo := bits.Mul64(b.epoch, uint64(time.Millisecond))
+	lo, carry := bits.Add64(lo, elo, 0)
+	hi, _ = bits.Add64(hi, ehi, carry)
+	return hi, lo
+}
+
+// Returns the time of timestamp ticks. Times after the largest [time.Time] that can be created
+// from Unix seconds are clamped to it.
+func (b timeBase) time(ticks uint64) time.Time {
+	hi, lo := b.nanos(ticks)
+	if hi >= uint64(time.Second) {
+		return time.Unix(maxUnixSeconds, 0)
+	}
+	sec, ns := bits.Div64(hi, lo, uint64(time.Second))
+	if sec > maxUnixSeconds {
+		return time.Unix(maxUnixSeconds, 0)
+	}
+	return time.Unix(int64(sec), int64(ns))
+}
+
+// Returns the time of timestamp ticks as milliseconds in unix format, rounded down. Clamped to
+// the largest uint64.
+func (b timeBase) unixMilli(ticks uint64) uint64 {
+	hi, lo := b.nanos(ticks)
+	if hi >= uint64(time.Millisecond) {
+		return math.MaxUint64
+	}
+	ms, _ := bits.Div64(hi, lo, uint64(time.Millisecond))
+	return ms
+}
+
+// Returns the duration of a number of ticks, clamped to the largest duration.
+func (b timeBase) duration(ticks uint64) time.Duration {
+	hi, lo := bits.Mul64(ticks, uint64(b.unit))
+	if hi > 0 || lo > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(lo)
+}
+
+// Returns the timestamp of t, rounded down to whole ticks, and checks that it is at most max.
+func (b timeBase) ticks(t time.Time, max uint64) (uint64, error) {
+	hi, lo := uint64(0), uint64(0)
+	if sec := t.Unix(); sec >= 0 {
+		hi, lo = bits.Mul64(uint64(sec), uint64(time.Second))
+		var carry uint64
+		lo, carry = bits.Add64(lo, uint64(t.Nanosecond()), 0)
+		hi += carry
+	}
+
+	ehi, elo := b.nanos(0)
+	lo, borrow := bits.Sub64(lo, elo, 0)
+	hi, borrow = bits.Sub64(hi, ehi, borrow)
+	if t.Unix() < 0 || borrow != 0 {
+		return 0, &TimeBeforeEpochError{SnowflakeError: SnowflakeError{
+			message: fmt.Sprintf("time %s is before epoch %d", t, b.epoch),
+		}}
+	}
+
+	if hi >= uint64(b.unit) {
+		return 0, b.overflow(t)
+	}
+	ticks, _ := bits.Div64(hi, lo, uint64(b.unit))
+	if ticks > max {
+		return 0, b.overflow(t)
+	}
+	return ticks, nil
+}
+
+func (b timeBase) overflow(t time.Time) *TimeOverflowError {
+	return &TimeOverflowError{SnowflakeError: SnowflakeError{
+		message: fmt.Sprintf("time %s is too far from epoch %d", t, b.epoch),
+	}}
+}
+
+// Returns the time unit, or one millisecond if unit is zero. Returns an error if unit is
+// negative.
+func timeUnit(unit time.Duration) (time.Duration, error) {
+	switch {
+	case unit == 0:
+		return time.Millisecond, nil
+	case unit < 0:
+		return 0, &SnowflakeError{message: fmt.Sprintf("time unit %s is negative", unit)}
+	}
+	return unit, nil
+}
@@ -0,0 +1,145 @@
+package snowflake_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gophercord/snowflake"
+)
+
+func TestTimeUnitDecode(t *testing.T) {
+	epoch := time.UnixMilli(snowflake.DiscordEpoch)
+	tests := []struct {
+		unit time.Duration
+		ts   uint64
+		want time.Time
+	}{
+		{time.Millisecond, 41944705796, time.Date(2016, 4, 30, 11, 18, 25, 796e6, time.UTC)},
+		{time.Microsecond, 1_500_001, epoch.Add(1_500_001 * time.Microsecond)},
+		{10 * time.Millisecond, 3, epoch.Add(30 * time.Millisecond)},
+		{time.Second, 1 << 40, time.Unix(snowflake.DiscordEpoch/1000+1<<40, 0)},
+		// The number of nanoseconds does not fit into 64 bits, but must still be exact.
+		{time.Hour, 1 << 41, time.Unix(snowflake.DiscordEpoch/1000+1<<41*3600, 0)},
+	}
+
+	for i, test := range tests {
+		s := snowflake.Snowflake(test.ts << 22)
+		d := snowflake.Decoder{Epoch: snowflake.DiscordEpoch, TimeUnit: test.unit}
+		if got := d.Time(s); !got.Equal(test.want) {
+			t.Errorf("FAIL TestTimeUnitDecode[%d]: decoder time %s, want %s", i, got, test.want)
+		}
+		if got := d.UnixMilli(s); got != uint64(test.want.UnixMilli()) {
+			t.Errorf("FAIL TestTimeUnitDecode[%d]: unix milli %d, want %d",
+				i, got, test.want.UnixMilli())
+		}
+		if got := d.ParseTime(test.want); got != s {
+			t.Errorf("FAIL TestTimeUnitDecode[%d]: parsed %d, want %d", i, got, s)
+		}
+
+		l := snowflake.MustNewLayout(snowflake.LayoutConfig{
+			TimestampBits: 42,
+			Epoch:         snowflake.DiscordEpoch,
+			TimeUnit:      test.unit,
+			Fields:        snowflake.LayoutDiscord.Fields(),
+		})
+		if got := l.Time(s); !got.Equal(test.want) {
+			t.Errorf("FAIL TestTimeUnitDecode[%d]: layout time %s, want %s", i, got, test.want)
+		}
+		if ts, err := l.TimestampOf(test.want.Add(test.unit - 1)); err != nil || ts != test.ts {
+			t.Errorf("FAIL TestTimeUnitDecode[%d]: timestamp %d, %v, want %d", i, ts, err, test.ts)
+		}
+	}
+}
+
+func TestTimeUnitMaxTime(t *testing.T) {
+	want := time.Date(2154, 5, 15, 7, 35, 11, 103e6, time.UTC)
+	if got := snowflake.LayoutDiscord.MaxTime(); !got.Equal(want) {
+		t.Errorf("FAIL TestTimeUnitMaxTime: discord layout %s, want %s", got, want)
+	}
+	if got := snowflake.DiscordDecoder.MaxTime(); !got.Equal(want) {
+		t.Errorf("FAIL TestTimeUnitMaxTime: discord decoder %s, want %s", got, want)
+	}
+
+	// 2^39 ticks of 10 ms since 2014-09-01: about 174 years.
+	want = time.Date(2188, 11, 16, 3, 28, 58, 870e6, time.UTC)
+	if got := snowflake.LayoutSonyflake.MaxTime(); !got.Equal(want) {
+		t.Errorf("FAIL TestTimeUnitMaxTime: sonyflake %s, want %s", got, want)
+	}
+
+	g := snowflake.MustNewGenerator(snowflake.GeneratorConfig{
+		Epoch:    snowflake.DiscordEpoch,
+		TimeUnit: time.Microsecond,
+	})
+	want = time.UnixMilli(snowflake.DiscordEpoch).Add(snowflake.MaxTimestamp * time.Microsecond)
+	if got := g.MaxTime(); !got.Equal(want) {
+		t.Errorf("FAIL TestTimeUnitMaxTime: generator %s, want %s", got, want)
+	}
+
+	// Timestamps beyond what time.Time can represent are clamped instead of wrapping around.
+	huge := snowflake.MustNewLayout(snowflake.LayoutConfig{TimestampBits: 64, TimeUnit: time.Hour})
+	if got := huge.MaxTime(); got.Year() < 10000 {
+		t.Errorf("FAIL TestTimeUnitMaxTime: huge layout %s wrapped around", got)
+	}
+
+	_, err := huge.TimestampOf(time.UnixMilli(-1))
+	if !sameErrorType(err, &snowflake.TimeBeforeEpochError{}) {
+		t.Errorf("FAIL TestTimeUnitMaxTime: wanted TimeBeforeEpochError, got %v", err)
+	}
+	after := snowflake.LayoutDiscord.MaxTime().Add(time.Millisecond)
+	_, err = snowflake.LayoutDiscord.TimestampOf(after)
+	if !sameErrorType(err, &snowflake.TimeOverflowError{}) {
+		t.Errorf("FAIL TestTimeUnitMaxTime: wanted TimeOverflowError, got %v", err)
+	}
+}
+
+func TestTimeUnitGenerator(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "generator.checkpoint")
+	// 42 bits of microseconds last only about 50 days, so the epoch must be recent.
+	epoch := uint64(start.UnixMilli())
+	clock := snowflake.NewManualClock(start.Add(1234567 * time.Nanosecond))
+	config := snowflake.GeneratorConfig{
+		Epoch:              epoch,
+		TimeUnit:           time.Microsecond,
+		Clock:              clock,
+		ClockPolicy:        snowflake.ClockPolicyError,
+		CheckpointPath:     path,
+		CheckpointInterval: -1,
+	}
+	d := snowflake.Decoder{Epoch: epoch, TimeUnit: time.Microsecond}
+
+	g := snowflake.MustNewGenerator(config)
+	first := g.MustGenerate()
+	if got, want := d.Time(first), start.Add(1234*time.Microsecond); !got.Equal(want) {
+		t.Errorf("FAIL TestTimeUnitGenerator: time %s, want %s", got, want)
+	}
+	if got := g.Stats().LastTime; !got.Equal(d.Time(first)) {
+		t.Errorf("FAIL TestTimeUnitGenerator: last time %s, want %s", got, d.Time(first))
+	}
+	g.Close()
+
+	// The checkpoint is stored in whole milliseconds, rounded up.
+	data, _ := os.ReadFile(path)
+	want := strconv.FormatInt(start.UnixMilli()+2, 10)
+	if got := strings.TrimSpace(string(data)); got != want {
+		t.Errorf("FAIL TestTimeUnitGenerator: checkpoint %s, want %s", got, want)
+	}
+
+	// A restarted generator must not issue IDs before the checkpoint.
+	g = snowflake.MustNewGenerator(config)
+	defer g.Close()
+	if _, err := g.Generate(); !sameErrorType(err, &snowflake.ClockMovedBackwardsError{}) {
+		t.Errorf("FAIL TestTimeUnitGenerator: wanted ClockMovedBackwardsError, got %v", err)
+	}
+	clock.Advance(time.Millisecond)
+	if s, err := g.Generate(); err != nil || s <= first {
+		t.Errorf("FAIL TestTimeUnitGenerator: got %d, %v, want above %d", s, err, first)
+	}
+
+	if _, err := snowflake.NewGenerator(snowflake.GeneratorConfig{TimeUnit: -1}); err == nil {
+		t.Errorf("FAIL TestTimeUnitGenerator: negative time unit accepted")
+	}
+}